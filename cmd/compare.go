@@ -0,0 +1,63 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/openpalettestandard/openpalette/internal/accessibility"
+	"github.com/spf13/cobra"
+)
+
+var compareCmd = &cobra.Command{
+	Use:   "compare",
+	Short: "Report nearest-neighbor color distances within each variant",
+	Long:  `Report the nearest neighbor of every color in each variant using a Delta E metric, closest pairs first, to catch near-duplicate colors.`,
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		configFile, _ := cmd.Flags().GetString("config")
+		format, _ := cmd.Flags().GetString("format")
+		metricName, _ := cmd.Flags().GetString("metric")
+		accentsOnly, _ := cmd.Flags().GetBool("accents")
+
+		metric, err := parseMetric(metricName)
+		if err != nil {
+			return err
+		}
+
+		paletteData, err := loadPalette(configFile)
+		if err != nil {
+			return err
+		}
+
+		reports := accessibility.CompareAll(paletteData, metric, accentsOnly)
+
+		switch format {
+		case "json":
+			data, err := json.MarshalIndent(reports, "", "  ")
+			if err != nil {
+				return fmt.Errorf("error marshaling report: %w", err)
+			}
+			fmt.Println(string(data))
+		case "text":
+			for _, report := range reports {
+				fmt.Printf("%s (%s)\n", report.Variant, report.Metric)
+				for _, neighbor := range report.Neighbors {
+					fmt.Printf("  %-10s nearest %-10s %6.2f\n", neighbor.Color, neighbor.Nearest, neighbor.Distance)
+				}
+			}
+		default:
+			return fmt.Errorf("unknown format %q (expected text or json)", format)
+		}
+
+		return nil
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(compareCmd)
+
+	compareCmd.Flags().StringP("config", "c", "", "Configuration file (JSON format)")
+	compareCmd.Flags().StringP("format", "f", "text", "Report format (text or json)")
+	compareCmd.Flags().StringP("metric", "m", string(accessibility.DefaultMetric), "Distance metric (cie76, cie94 or ciede2000)")
+	compareCmd.Flags().BoolP("accents", "a", false, "Only compare accent colors")
+}
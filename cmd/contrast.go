@@ -0,0 +1,67 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/openpalettestandard/openpalette/internal/accessibility"
+	"github.com/spf13/cobra"
+)
+
+var contrastCmd = &cobra.Command{
+	Use:   "contrast",
+	Short: "Report WCAG and APCA contrast for each variant",
+	Long:  `Report the WCAG 2.x contrast ratio and APCA Lc value of every required, recommended and semantic text/surface pair in each variant.`,
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		configFile, _ := cmd.Flags().GetString("config")
+		format, _ := cmd.Flags().GetString("format")
+
+		paletteData, err := loadPalette(configFile)
+		if err != nil {
+			return err
+		}
+
+		reports := accessibility.ContrastAll(paletteData)
+
+		switch format {
+		case "json":
+			data, err := json.MarshalIndent(reports, "", "  ")
+			if err != nil {
+				return fmt.Errorf("error marshaling report: %w", err)
+			}
+			fmt.Println(string(data))
+		case "text":
+			for _, report := range reports {
+				fmt.Printf("%s\n", report.Variant)
+				for _, pair := range report.Pairs {
+					font := pair.Font.Usage
+					if pair.Font.Readable {
+						font = fmt.Sprintf("%dpx/400 or %dpx/700 (%s)", pair.Font.MinSize, pair.Font.BoldSize, pair.Font.Usage)
+					}
+					fmt.Printf("  %-13s %-10s on %-8s %6.2f:1  AA %-4s AAA %-4s  Lc %6.1f  %s\n",
+						pair.Level, pair.Foreground, pair.Background, pair.Ratio,
+						passFail(pair.AA), passFail(pair.AAA), pair.APCA, font)
+				}
+			}
+		default:
+			return fmt.Errorf("unknown format %q (expected text or json)", format)
+		}
+
+		return nil
+	},
+}
+
+func passFail(pass bool) string {
+	if pass {
+		return "pass"
+	}
+	return "fail"
+}
+
+func init() {
+	rootCmd.AddCommand(contrastCmd)
+
+	contrastCmd.Flags().StringP("config", "c", "", "Configuration file (JSON format)")
+	contrastCmd.Flags().StringP("format", "f", "text", "Report format (text or json)")
+}
@@ -0,0 +1,68 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/openpalettestandard/openpalette/internal/accessibility"
+	"github.com/spf13/cobra"
+)
+
+var cvdCmd = &cobra.Command{
+	Use:   "cvd",
+	Short: "Check accent distinguishability under color vision deficiencies",
+	Long:  `Compute the pairwise distance between every accent of each variant under normal vision and simulated protanopia, deuteranopia and tritanopia, and flag pairs below a threshold.`,
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		configFile, _ := cmd.Flags().GetString("config")
+		format, _ := cmd.Flags().GetString("format")
+		threshold, _ := cmd.Flags().GetFloat64("threshold")
+		metricName, _ := cmd.Flags().GetString("metric")
+
+		metric, err := parseMetric(metricName)
+		if err != nil {
+			return err
+		}
+
+		paletteData, err := loadPalette(configFile)
+		if err != nil {
+			return err
+		}
+
+		reports := accessibility.DistinguishAll(paletteData, metric, threshold)
+
+		switch format {
+		case "json":
+			data, err := json.MarshalIndent(reports, "", "  ")
+			if err != nil {
+				return fmt.Errorf("error marshaling report: %w", err)
+			}
+			fmt.Println(string(data))
+		case "text":
+			for _, report := range reports {
+				fmt.Printf("%s (%s threshold %.1f)\n", report.Variant, report.Metric, report.Threshold)
+				for _, vision := range accessibility.Visions() {
+					if closest, exists := report.Closest[vision]; exists {
+						fmt.Printf("  %-13s closest %s/%s at %.2f\n", vision, closest.A, closest.B, closest.Distance)
+					}
+				}
+				for _, pair := range report.Flagged {
+					fmt.Printf("  flagged %-13s %-10s %-10s %6.2f\n", pair.Vision, pair.A, pair.B, pair.Distance)
+				}
+			}
+		default:
+			return fmt.Errorf("unknown format %q (expected text or json)", format)
+		}
+
+		return nil
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(cvdCmd)
+
+	cvdCmd.Flags().StringP("config", "c", "", "Configuration file (JSON format)")
+	cvdCmd.Flags().StringP("format", "f", "text", "Report format (text or json)")
+	cvdCmd.Flags().Float64P("threshold", "t", accessibility.DefaultDistanceThreshold, "Minimum distance between two accents")
+	cvdCmd.Flags().StringP("metric", "m", string(accessibility.DefaultMetric), "Distance metric (cie76, cie94 or ciede2000)")
+}
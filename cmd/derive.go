@@ -0,0 +1,94 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/openpalettestandard/openpalette/internal/palette"
+	"github.com/spf13/cobra"
+)
+
+var deriveCmd = &cobra.Command{
+	Use:   "derive",
+	Short: "Derive the opposite light or dark variant of a variant",
+	Long:  `Derive a light variant from a dark one, or a dark variant from a light one, and write it as a configuration file that you can tweak before generating.`,
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		configFile, _ := cmd.Flags().GetString("config")
+		variantID, _ := cmd.Flags().GetString("variant")
+		newID, _ := cmd.Flags().GetString("id")
+		name, _ := cmd.Flags().GetString("name")
+		outputFile, _ := cmd.Flags().GetString("output")
+
+		rawVariants, version, err := palette.LoadFromFile(configFile)
+		if err != nil {
+			return fmt.Errorf("failed to load config: %w", err)
+		}
+
+		if variantID == "" && len(rawVariants) == 1 {
+			variantID = rawVariants[0].ID
+		}
+
+		var derived *palette.ConfigVariant
+		var naming string
+		for _, rawVariant := range rawVariants {
+			if rawVariant.ID != variantID {
+				continue
+			}
+
+			variant, err := palette.Derive(rawVariant)
+			if err != nil {
+				return fmt.Errorf("failed to derive variant: %w", err)
+			}
+			if newID == "" {
+				newID = rawVariant.ID + "-dark"
+				if !variant.Dark {
+					newID = rawVariant.ID + "-light"
+				}
+			}
+			derived = &variant
+			naming = rawVariant.Naming
+		}
+		if derived == nil {
+			return fmt.Errorf("variant %q not found", variantID)
+		}
+
+		if name != "" {
+			derived.Name = name
+		}
+
+		config := palette.ConfigFile{
+			Version:  version,
+			Naming:   naming,
+			Variants: map[string]palette.ConfigVariant{newID: *derived},
+		}
+
+		data, err := json.MarshalIndent(config, "", "  ")
+		if err != nil {
+			return fmt.Errorf("error marshaling config: %w", err)
+		}
+
+		if outputFile == "" {
+			fmt.Println(string(data))
+			return nil
+		}
+
+		if err := os.WriteFile(outputFile, data, 0644); err != nil {
+			return fmt.Errorf("error writing config file: %w", err)
+		}
+		fmt.Printf("Derived %s from %s: %s\n", newID, variantID, outputFile)
+
+		return nil
+	},
+}
+
+func init() {
+	generateCmd.AddCommand(deriveCmd)
+
+	deriveCmd.Flags().StringP("config", "c", "", "Configuration file (JSON format)")
+	deriveCmd.Flags().String("variant", "", "Variant to derive from (defaults to the only variant)")
+	deriveCmd.Flags().String("id", "", "ID of the derived variant (defaults to <variant>-light or <variant>-dark)")
+	deriveCmd.Flags().String("name", "", "Name of the derived variant")
+	deriveCmd.Flags().StringP("output", "o", "", "Output config file")
+}
@@ -0,0 +1,88 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/openpalettestandard/openpalette/internal/export"
+	"github.com/spf13/cobra"
+)
+
+var exportCmd = &cobra.Command{
+	Use:   "export",
+	Short: "Export the palette for a platform",
+	Long: `Export each variant of the palette in a platform format. With --out, one file per variant is written under <out>/ports/<port>/, following the layout in section 8.1 of the specification; otherwise the export is written to stdout. The palette is read from --palette, or generated from --config or the default palette. Formats that switch between variants themselves, such as the Tailwind ones, write a single file for all of them.
+
+Formats: ` + strings.Join(export.Names(), ", "),
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		paletteFile, _ := cmd.Flags().GetString("palette")
+		configFile, _ := cmd.Flags().GetString("config")
+		format, _ := cmd.Flags().GetString("format")
+		variants, _ := cmd.Flags().GetStringSlice("variant")
+		outDir, _ := cmd.Flags().GetString("out")
+		styleName, _ := cmd.Flags().GetString("style")
+
+		exporter, err := export.Lookup(format)
+		if err != nil {
+			return err
+		}
+
+		style, err := export.ParseStyle(styleName)
+		if err != nil {
+			return err
+		}
+		opts := export.Options{Style: style}
+
+		paletteData, err := loadPaletteFile(paletteFile, configFile)
+		if err != nil {
+			return err
+		}
+
+		variantIDs, err := export.SelectVariants(paletteData, variants)
+		if err != nil {
+			return err
+		}
+
+		if outDir == "" {
+			if combined, ok := exporter.(export.Combined); ok {
+				if err := combined.ExportCombined(os.Stdout, paletteData, variantIDs, opts); err != nil {
+					return fmt.Errorf("failed to export: %w", err)
+				}
+				return nil
+			}
+
+			for _, id := range variantIDs {
+				if err := exporter.Export(os.Stdout, paletteData, id, opts); err != nil {
+					return fmt.Errorf("failed to export %s: %w", id, err)
+				}
+			}
+			return nil
+		}
+
+		written, err := export.WriteFiles(exporter, paletteData, variantIDs, outDir, opts)
+		if err != nil {
+			return fmt.Errorf("failed to export: %w", err)
+		}
+		for _, filename := range written {
+			fmt.Printf("Exported %s\n", filename)
+		}
+
+		return nil
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(exportCmd)
+
+	exportCmd.Flags().StringP("format", "f", "", "Export format ("+strings.Join(export.Names(), ", ")+")")
+	exportCmd.Flags().StringP("palette", "p", "", "Generated palette.json to export")
+	exportCmd.Flags().StringP("config", "c", "", "Configuration file (JSON format)")
+	exportCmd.Flags().StringSlice("variant", nil, "Variant to export (repeat for several, defaults to all)")
+	exportCmd.Flags().String("out", "", "Output directory for the ports/ tree")
+	exportCmd.Flags().String("style", "hex", "Color value style for web formats (hex, rgb or oklch)")
+
+	exportCmd.MarkFlagRequired("format")
+	exportCmd.MarkFlagsMutuallyExclusive("palette", "config")
+}
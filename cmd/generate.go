@@ -23,23 +23,44 @@ var paletteCmd = &cobra.Command{
 		outputFile, _ := cmd.Flags().GetString("output")
 		configFile, _ := cmd.Flags().GetString("config")
 		versionFlag, _ := cmd.Flags().GetString("version")
+		includeOKLCH, _ := cmd.Flags().GetBool("oklch")
+		includeWideGamut, _ := cmd.Flags().GetBool("wide-gamut")
+		includeANSI256, _ := cmd.Flags().GetBool("ansi256")
+		naming, _ := cmd.Flags().GetString("naming")
+
+		if naming != "" {
+			if _, err := palette.LookupNameSet(naming); err != nil {
+				return err
+			}
+		}
+
+		opts := palette.Options{
+			IncludeOKLCH:     includeOKLCH,
+			IncludeWideGamut: includeWideGamut,
+			IncludeANSI256:   includeANSI256,
+			Naming:           naming,
+		}
 
 		var paletteData types.PaletteResult
 		var err error
 
 		if configFile != "" {
-			paletteData, err = palette.GenerateFromConfig(configFile)
+			paletteData, err = palette.GenerateFromConfig(configFile, opts)
 			if err != nil {
 				return fmt.Errorf("failed to generate from config: %w", err)
 			}
 		} else {
-			paletteData = palette.Generate()
+			paletteData = palette.Generate(opts)
 		}
 
 		if versionFlag != "" {
 			paletteData.Version = versionFlag
 		}
 
+		for _, warning := range append(palette.ANSIWarnings(paletteData), palette.GamutWarnings(paletteData)...) {
+			fmt.Fprintf(os.Stderr, "warning: %s\n", warning)
+		}
+
 		if outputFile == "" {
 			if err := types.WriteJSON(paletteData, os.Stdout); err != nil {
 				return fmt.Errorf("error writing JSON to stdout: %w", err)
@@ -85,6 +106,10 @@ func init() {
 	paletteCmd.Flags().StringP("output", "o", "", "Output file path")
 	paletteCmd.Flags().StringP("config", "c", "", "Configuration file (JSON format)")
 	paletteCmd.Flags().StringP("version", "v", "", "Palette version (overrides config)")
+	paletteCmd.Flags().Bool("oklch", false, "Include OKLCH values for every color")
+	paletteCmd.Flags().Bool("wide-gamut", false, "Include Display P3 and Rec.2020 values for every color")
+	paletteCmd.Flags().Bool("ansi256", false, "Include the xterm 256-color palette interpolated from the variant")
+	paletteCmd.Flags().String("naming", "", "Color naming scheme for output (catppuccin or openpalette, overrides config)")
 
 	exampleCmd.Flags().StringP("output", "o", "", "Output config file")
 }
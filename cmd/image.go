@@ -0,0 +1,76 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/openpalettestandard/openpalette/internal/palette"
+	"github.com/spf13/cobra"
+)
+
+var imageCmd = &cobra.Command{
+	Use:   "image <file>",
+	Short: "Generate a configuration from a PNG or JPEG image",
+	Long:  `Generate a configuration file by clustering the pixels of a PNG or JPEG image in OKLab. The most frequent colorful clusters seed the 14 accents and base and text come from the near-neutral areas closest to the usual background and text lightness, adjusted into that range. The same image and --seed always give the same result. Use the result with "generate palette -c".`,
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		clusters, _ := cmd.Flags().GetInt("clusters")
+		seed, _ := cmd.Flags().GetInt64("seed")
+		polarity, _ := cmd.Flags().GetString("polarity")
+		id, _ := cmd.Flags().GetString("id")
+		name, _ := cmd.Flags().GetString("name")
+		naming, _ := cmd.Flags().GetString("naming")
+		outputFile, _ := cmd.Flags().GetString("output")
+
+		opts := palette.ImageOptions{
+			Clusters: clusters,
+			Seed:     seed,
+			ID:       id,
+			Name:     name,
+			Naming:   naming,
+		}
+		switch polarity {
+		case "auto":
+		case "dark", "light":
+			dark := polarity == "dark"
+			opts.Dark = &dark
+		default:
+			return fmt.Errorf("unknown polarity %q (expected auto, dark or light)", polarity)
+		}
+
+		config, err := palette.ExtractFromFile(args[0], opts)
+		if err != nil {
+			return fmt.Errorf("failed to extract palette: %w", err)
+		}
+
+		data, err := json.MarshalIndent(config, "", "  ")
+		if err != nil {
+			return fmt.Errorf("error marshaling config: %w", err)
+		}
+
+		if outputFile == "" {
+			fmt.Println(string(data))
+			return nil
+		}
+
+		if err := os.WriteFile(outputFile, data, 0644); err != nil {
+			return fmt.Errorf("error writing config file: %w", err)
+		}
+		fmt.Printf("Generated config from %s: %s\n", args[0], outputFile)
+
+		return nil
+	},
+}
+
+func init() {
+	generateCmd.AddCommand(imageCmd)
+
+	imageCmd.Flags().IntP("clusters", "k", palette.DefaultClusters, "Number of k-means clusters")
+	imageCmd.Flags().Int64("seed", 0, "Random seed for clustering")
+	imageCmd.Flags().String("polarity", "auto", "Variant polarity (auto, dark or light)")
+	imageCmd.Flags().String("id", "", "Variant ID (defaults to dark or light)")
+	imageCmd.Flags().String("name", "", "Variant name")
+	imageCmd.Flags().String("naming", "", "Color naming scheme (catppuccin or openpalette)")
+	imageCmd.Flags().StringP("output", "o", "", "Output config file")
+}
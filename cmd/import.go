@@ -0,0 +1,55 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/openpalettestandard/openpalette/internal/palette"
+	"github.com/spf13/cobra"
+)
+
+var importCmd = &cobra.Command{
+	Use:   "import",
+	Short: "Import a palette from another format",
+	Long:  `Import a palette from another format into a configuration file`,
+}
+
+var importDTCGCmd = &cobra.Command{
+	Use:   "dtcg <file>...",
+	Short: "Import W3C Design Tokens (DTCG) files",
+	Long:  `Import W3C Design Tokens Community Group JSON files, such as those from Style Dictionary or Tokens Studio, into a configuration file with one variant per file. ANSI and translucent tokens are skipped, since the generator derives them. Use the result with "generate palette -c".`,
+	Args:  cobra.MinimumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		outputFile, _ := cmd.Flags().GetString("output")
+
+		config, err := palette.ImportDTCG(args)
+		if err != nil {
+			return fmt.Errorf("failed to import tokens: %w", err)
+		}
+
+		data, err := json.MarshalIndent(config, "", "  ")
+		if err != nil {
+			return fmt.Errorf("error marshaling config: %w", err)
+		}
+
+		if outputFile == "" {
+			fmt.Println(string(data))
+			return nil
+		}
+
+		if err := os.WriteFile(outputFile, data, 0644); err != nil {
+			return fmt.Errorf("error writing config file: %w", err)
+		}
+		fmt.Printf("Imported %d variant(s): %s\n", len(config.Variants), outputFile)
+
+		return nil
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(importCmd)
+	importCmd.AddCommand(importDTCGCmd)
+
+	importDTCGCmd.Flags().StringP("output", "o", "", "Output config file")
+}
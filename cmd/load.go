@@ -0,0 +1,44 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/openpalettestandard/openpalette/internal/color"
+	"github.com/openpalettestandard/openpalette/internal/palette"
+	"github.com/openpalettestandard/openpalette/internal/types"
+)
+
+func loadPalette(configFile string) (types.PaletteResult, error) {
+	if configFile == "" {
+		return palette.Generate(palette.Options{}), nil
+	}
+
+	paletteData, err := palette.GenerateFromConfig(configFile, palette.Options{})
+	if err != nil {
+		return types.PaletteResult{}, fmt.Errorf("failed to generate from config: %w", err)
+	}
+	return paletteData, nil
+}
+
+// loadPaletteFile reads an existing palette.json when paletteFile is set and
+// otherwise generates the palette like loadPalette.
+func loadPaletteFile(paletteFile, configFile string) (types.PaletteResult, error) {
+	if paletteFile == "" {
+		return loadPalette(configFile)
+	}
+
+	paletteData, err := types.ReadJSONFile(paletteFile, false)
+	if err != nil {
+		return types.PaletteResult{}, fmt.Errorf("failed to read palette: %w", err)
+	}
+	return paletteData, nil
+}
+
+func parseMetric(name string) (color.Metric, error) {
+	for _, metric := range color.Metrics {
+		if string(metric) == name {
+			return metric, nil
+		}
+	}
+	return "", fmt.Errorf("unknown metric %q (expected cie76, cie94 or ciede2000)", name)
+}
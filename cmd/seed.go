@@ -0,0 +1,72 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/openpalettestandard/openpalette/internal/palette"
+	"github.com/spf13/cobra"
+)
+
+var seedCmd = &cobra.Command{
+	Use:   "seed",
+	Short: "Generate a configuration from one or more seed colors",
+	Long:  `Generate a configuration file with 14 accents spread around the hue wheel from one or more seed colors, tuned for contrast against the base color. Use the result with "generate palette -c".`,
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		seeds, _ := cmd.Flags().GetStringArray("color")
+		base, _ := cmd.Flags().GetString("base")
+		text, _ := cmd.Flags().GetString("text")
+		light, _ := cmd.Flags().GetBool("light")
+		id, _ := cmd.Flags().GetString("id")
+		name, _ := cmd.Flags().GetString("name")
+		naming, _ := cmd.Flags().GetString("naming")
+		outputFile, _ := cmd.Flags().GetString("output")
+
+		config, err := palette.GenerateFromSeeds(palette.SeedOptions{
+			Seeds:  seeds,
+			Base:   base,
+			Text:   text,
+			Dark:   !light,
+			ID:     id,
+			Name:   name,
+			Naming: naming,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to generate from seeds: %w", err)
+		}
+
+		data, err := json.MarshalIndent(config, "", "  ")
+		if err != nil {
+			return fmt.Errorf("error marshaling config: %w", err)
+		}
+
+		if outputFile == "" {
+			fmt.Println(string(data))
+			return nil
+		}
+
+		if err := os.WriteFile(outputFile, data, 0644); err != nil {
+			return fmt.Errorf("error writing config file: %w", err)
+		}
+		fmt.Printf("Generated config from %d seed(s): %s\n", len(seeds), outputFile)
+
+		return nil
+	},
+}
+
+func init() {
+	generateCmd.AddCommand(seedCmd)
+
+	seedCmd.Flags().StringArray("color", nil, "Seed color in any CSS syntax (repeat for several seeds)")
+	seedCmd.Flags().String("base", "", "Base color (defaults to a neutral tinted with the first seed)")
+	seedCmd.Flags().String("text", "", "Text color (defaults to a neutral tinted with the first seed)")
+	seedCmd.Flags().Bool("light", false, "Generate a light variant instead of a dark one")
+	seedCmd.Flags().String("id", "", "Variant ID (defaults to dark or light)")
+	seedCmd.Flags().String("name", "", "Variant name")
+	seedCmd.Flags().String("naming", "", "Color naming scheme (catppuccin or openpalette)")
+	seedCmd.Flags().StringP("output", "o", "", "Output config file")
+
+	seedCmd.MarkFlagRequired("color")
+}
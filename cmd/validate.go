@@ -0,0 +1,61 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/openpalettestandard/openpalette/internal/validate"
+	"github.com/spf13/cobra"
+)
+
+var validateCmd = &cobra.Command{
+	Use:   "validate <file>",
+	Short: "Validate a palette or configuration file",
+	Long:  `Validate a generated palette.json or a configuration file against the OpenPalette validation rules.`,
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		format, _ := cmd.Flags().GetString("format")
+
+		report, err := validate.File(args[0])
+		if err != nil {
+			return fmt.Errorf("failed to validate %s: %w", args[0], err)
+		}
+
+		switch format {
+		case "json":
+			data, err := json.MarshalIndent(report, "", "  ")
+			if err != nil {
+				return fmt.Errorf("error marshaling report: %w", err)
+			}
+			fmt.Fprintln(os.Stdout, string(data))
+		case "text":
+			for _, finding := range report.Findings {
+				location := finding.Variant
+				if finding.Color != "" {
+					location += "." + finding.Color
+				}
+				fmt.Printf("%-6s %-4s %-16s %-22s %s\n",
+					finding.Severity, finding.Section, finding.Rule, location, finding.Message)
+			}
+			fmt.Printf("%s: %d MUST failure(s), %d SHOULD warning(s), %d extension color(s)\n",
+				args[0], report.Count(validate.SeverityMust), report.Count(validate.SeverityShould), report.Count(validate.SeverityInfo))
+		default:
+			return fmt.Errorf("unknown format %q (expected text or json)", format)
+		}
+
+		if report.Failed() {
+			cmd.SilenceUsage = true
+			cmd.SilenceErrors = true
+			return fmt.Errorf("%s is not OpenPalette-compliant", args[0])
+		}
+
+		return nil
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(validateCmd)
+
+	validateCmd.Flags().StringP("format", "f", "text", "Report format (text or json)")
+}
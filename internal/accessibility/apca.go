@@ -0,0 +1,34 @@
+package accessibility
+
+import "math"
+
+type FontRecommendation struct {
+	MinSize  int    `json:"minSize"`
+	BoldSize int    `json:"boldSize"`
+	Usage    string `json:"usage"`
+	Readable bool   `json:"readable"`
+}
+
+// apcaLevels follows the APCA "bronze simple" conformance levels. MinSize is
+// the smallest size in px at weight 400 and BoldSize the smallest at weight 700.
+var apcaLevels = []struct {
+	lc   float64
+	font FontRecommendation
+}{
+	{lc: 90, font: FontRecommendation{MinSize: 14, BoldSize: 14, Usage: "preferred for body text", Readable: true}},
+	{lc: 75, font: FontRecommendation{MinSize: 18, BoldSize: 14, Usage: "body text", Readable: true}},
+	{lc: 60, font: FontRecommendation{MinSize: 24, BoldSize: 16, Usage: "content text outside body columns", Readable: true}},
+	{lc: 45, font: FontRecommendation{MinSize: 36, BoldSize: 24, Usage: "headlines and large text", Readable: true}},
+	{lc: 30, font: FontRecommendation{Usage: "placeholder and disabled text only"}},
+	{lc: 15, font: FontRecommendation{Usage: "non-text elements only"}},
+}
+
+func RecommendFont(lc float64) FontRecommendation {
+	abs := math.Abs(lc)
+	for _, level := range apcaLevels {
+		if abs >= level.lc {
+			return level.font
+		}
+	}
+	return FontRecommendation{Usage: "invisible"}
+}
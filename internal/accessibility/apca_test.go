@@ -0,0 +1,29 @@
+package accessibility
+
+import "testing"
+
+func TestRecommendFont(t *testing.T) {
+	tests := []struct {
+		lc       float64
+		minSize  int
+		boldSize int
+		readable bool
+	}{
+		{lc: 106, minSize: 14, boldSize: 14, readable: true},
+		{lc: 90, minSize: 14, boldSize: 14, readable: true},
+		{lc: 75, minSize: 18, boldSize: 14, readable: true},
+		{lc: -75, minSize: 18, boldSize: 14, readable: true},
+		{lc: 74.9, minSize: 24, boldSize: 16, readable: true},
+		{lc: 60, minSize: 24, boldSize: 16, readable: true},
+		{lc: 45, minSize: 36, boldSize: 24, readable: true},
+		{lc: 30, readable: false},
+		{lc: 10, readable: false},
+	}
+
+	for _, tt := range tests {
+		font := RecommendFont(tt.lc)
+		if font.MinSize != tt.minSize || font.BoldSize != tt.boldSize || font.Readable != tt.readable {
+			t.Errorf("Lc %v: expected %dpx at 400, %dpx at 700, readable %t, got %+v", tt.lc, tt.minSize, tt.boldSize, tt.readable, font)
+		}
+	}
+}
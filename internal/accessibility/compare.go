@@ -0,0 +1,86 @@
+package accessibility
+
+import (
+	"sort"
+
+	"github.com/openpalettestandard/openpalette/internal/color"
+	"github.com/openpalettestandard/openpalette/internal/types"
+)
+
+type Neighbor struct {
+	Color    string  `json:"color"`
+	Nearest  string  `json:"nearest"`
+	Distance float64 `json:"distance"`
+}
+
+type CompareReport struct {
+	Variant   string       `json:"variant"`
+	Metric    color.Metric `json:"metric"`
+	Neighbors []Neighbor   `json:"neighbors"`
+}
+
+func colorIDs(variant types.PaletteVariant, accentsOnly bool) []string {
+	if accentsOnly {
+		return accentIDs(variant)
+	}
+
+	ids := make([]string, 0, len(variant.PaletteColors))
+	for id := range variant.PaletteColors {
+		ids = append(ids, id)
+	}
+	sort.Slice(ids, func(i, j int) bool {
+		a, b := variant.PaletteColors[ids[i]], variant.PaletteColors[ids[j]]
+		if a.Order != b.Order {
+			return a.Order < b.Order
+		}
+		return ids[i] < ids[j]
+	})
+	return ids
+}
+
+// Compare lists the nearest neighbor of every color in the variant, closest
+// pairs first.
+func Compare(variantID string, variant types.PaletteVariant, metric color.Metric, accentsOnly bool) CompareReport {
+	report := CompareReport{Variant: variantID, Metric: metric}
+
+	ids := colorIDs(variant, accentsOnly)
+	colors := make([]*color.Color, len(ids))
+	for i, id := range ids {
+		colors[i] = color.NewColor(variant.PaletteColors[id].Hex)
+	}
+
+	for i, id := range ids {
+		neighbor := Neighbor{Color: id}
+		for j := range ids {
+			if i == j {
+				continue
+			}
+
+			distance := color.Distance(metric, colors[i], colors[j])
+			if neighbor.Nearest == "" || distance < neighbor.Distance {
+				neighbor.Nearest = ids[j]
+				neighbor.Distance = distance
+			}
+		}
+
+		if neighbor.Nearest != "" {
+			report.Neighbors = append(report.Neighbors, neighbor)
+		}
+	}
+
+	sort.SliceStable(report.Neighbors, func(i, j int) bool {
+		return report.Neighbors[i].Distance < report.Neighbors[j].Distance
+	})
+
+	return report
+}
+
+func CompareAll(result types.PaletteResult, metric color.Metric, accentsOnly bool) []CompareReport {
+	ids := variantIDs(result)
+
+	reports := make([]CompareReport, 0, len(ids))
+	for _, id := range ids {
+		reports = append(reports, Compare(id, result.Variants[id], metric, accentsOnly))
+	}
+	return reports
+}
@@ -0,0 +1,49 @@
+package accessibility
+
+import (
+	"testing"
+
+	"github.com/openpalettestandard/openpalette/internal/color"
+)
+
+func TestCompare(t *testing.T) {
+	variant := testVariant(map[string]testColor{
+		"red":     {hex: "#d20f39", accent: true},
+		"crimson": {hex: "#d3123b", accent: true},
+		"blue":    {hex: "#1e66f5", accent: true},
+		"sky":     {hex: "#04a5e5", accent: true},
+		"base":    {hex: "#eff1f5"},
+	}, "red", "crimson", "blue", "sky", "base")
+
+	report := Compare("latte", variant, color.CIEDE2000, false)
+	if len(report.Neighbors) != 5 {
+		t.Fatalf("expected a neighbor for each of the 5 colors, got %+v", report.Neighbors)
+	}
+
+	for i := 1; i < len(report.Neighbors); i++ {
+		if report.Neighbors[i].Distance < report.Neighbors[i-1].Distance {
+			t.Errorf("expected closest pairs first, got %+v", report.Neighbors)
+		}
+	}
+
+	nearest := make(map[string]string)
+	for _, neighbor := range report.Neighbors {
+		nearest[neighbor.Color] = neighbor.Nearest
+	}
+	if first := report.Neighbors[0]; first.Color != "red" || first.Nearest != "crimson" {
+		t.Errorf("expected red and crimson first, got %+v", first)
+	}
+	if nearest["crimson"] != "red" || nearest["blue"] != "sky" || nearest["sky"] != "blue" {
+		t.Errorf("unexpected nearest neighbors %v", nearest)
+	}
+
+	accents := Compare("latte", variant, color.CIEDE2000, true)
+	for _, neighbor := range accents.Neighbors {
+		if neighbor.Color == "base" || neighbor.Nearest == "base" {
+			t.Errorf("expected only accents, got %+v", neighbor)
+		}
+	}
+	if len(accents.Neighbors) != 4 {
+		t.Errorf("expected 4 accent neighbors, got %+v", accents.Neighbors)
+	}
+}
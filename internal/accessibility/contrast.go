@@ -0,0 +1,146 @@
+package accessibility
+
+import (
+	"sort"
+
+	"github.com/openpalettestandard/openpalette/internal/color"
+	"github.com/openpalettestandard/openpalette/internal/types"
+)
+
+const (
+	RatioAA  = 4.5
+	RatioAAA = 7.0
+)
+
+type Level string
+
+const (
+	LevelRequired      Level = "required"
+	LevelRecommended   Level = "recommended"
+	LevelInformational Level = "informational"
+)
+
+type ContrastResult struct {
+	Foreground string             `json:"foreground"`
+	Background string             `json:"background"`
+	Level      Level              `json:"level"`
+	Ratio      float64            `json:"ratio"`
+	AA         bool               `json:"aa"`
+	AAA        bool               `json:"aaa"`
+	APCA       float64            `json:"apca"`
+	Font       FontRecommendation `json:"font"`
+}
+
+type ContrastReport struct {
+	Variant string           `json:"variant"`
+	Pairs   []ContrastResult `json:"pairs"`
+}
+
+func (r ContrastReport) Failures(level Level) []ContrastResult {
+	var failures []ContrastResult
+	for _, pair := range r.Pairs {
+		if pair.Level == level && !pair.AA {
+			failures = append(failures, pair)
+		}
+	}
+	return failures
+}
+
+type contrastPair struct {
+	foreground string
+	background string
+	level      Level
+}
+
+var (
+	textElements    = []string{"text", "subtext1", "subtext0"}
+	surfaceElements = []string{"mantle", "crust", "surface0", "surface1", "surface2"}
+)
+
+func contrastPairs(variant types.PaletteVariant) []contrastPair {
+	pairs := []contrastPair{
+		{foreground: "text", background: "base", level: LevelRequired},
+		{foreground: "subtext1", background: "base", level: LevelRequired},
+		{foreground: "subtext0", background: "base", level: LevelRecommended},
+	}
+
+	var accents []string
+	for id, c := range variant.PaletteColors {
+		if c.Accent {
+			accents = append(accents, id)
+		}
+	}
+	sort.Slice(accents, func(i, j int) bool {
+		a, b := variant.PaletteColors[accents[i]], variant.PaletteColors[accents[j]]
+		if a.Order != b.Order {
+			return a.Order < b.Order
+		}
+		return accents[i] < accents[j]
+	})
+
+	for _, id := range accents {
+		pairs = append(pairs, contrastPair{foreground: id, background: "base", level: LevelRecommended})
+	}
+
+	for _, background := range surfaceElements {
+		for _, foreground := range textElements {
+			pairs = append(pairs, contrastPair{foreground: foreground, background: background, level: LevelInformational})
+		}
+	}
+
+	return pairs
+}
+
+func Contrast(variantID string, variant types.PaletteVariant) ContrastReport {
+	report := ContrastReport{Variant: variantID}
+
+	for _, pair := range contrastPairs(variant) {
+		fg, fgExists := variant.PaletteColors[pair.foreground]
+		bg, bgExists := variant.PaletteColors[pair.background]
+		if !fgExists || !bgExists {
+			continue
+		}
+
+		fgColor, bgColor := color.NewColor(fg.Hex), color.NewColor(bg.Hex)
+		ratio := color.ContrastRatio(fgColor, bgColor)
+		lc := color.APCAContrast(fgColor, bgColor)
+
+		report.Pairs = append(report.Pairs, ContrastResult{
+			Foreground: pair.foreground,
+			Background: pair.background,
+			Level:      pair.level,
+			Ratio:      ratio,
+			AA:         ratio >= RatioAA,
+			AAA:        ratio >= RatioAAA,
+			APCA:       lc,
+			Font:       RecommendFont(lc),
+		})
+	}
+
+	return report
+}
+
+func ContrastAll(result types.PaletteResult) []ContrastReport {
+	ids := variantIDs(result)
+
+	reports := make([]ContrastReport, 0, len(ids))
+	for _, id := range ids {
+		reports = append(reports, Contrast(id, result.Variants[id]))
+	}
+	return reports
+}
+
+func variantIDs(result types.PaletteResult) []string {
+	ids := make([]string, 0, len(result.Variants))
+	for id := range result.Variants {
+		ids = append(ids, id)
+	}
+	sort.Slice(ids, func(i, j int) bool {
+		a, b := result.Variants[ids[i]], result.Variants[ids[j]]
+		if a.Order != b.Order {
+			return a.Order < b.Order
+		}
+		return ids[i] < ids[j]
+	})
+	return ids
+}
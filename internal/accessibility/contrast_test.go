@@ -0,0 +1,70 @@
+package accessibility
+
+import (
+	"testing"
+
+	"github.com/openpalettestandard/openpalette/internal/types"
+)
+
+type testColor struct {
+	hex    string
+	accent bool
+}
+
+func testVariant(colors map[string]testColor, order ...string) types.PaletteVariant {
+	variant := types.PaletteVariant{PaletteColors: make(map[string]types.PaletteColor)}
+	for i, id := range order {
+		c := colors[id]
+		variant.PaletteColors[id] = types.PaletteColor{Name: id, Order: i, Hex: c.hex, Accent: c.accent}
+	}
+	return variant
+}
+
+func TestContrast(t *testing.T) {
+	variant := testVariant(map[string]testColor{
+		"red":      {hex: "#d20f39", accent: true},
+		"yellow":   {hex: "#df8e1d", accent: true},
+		"text":     {hex: "#4c4f69"},
+		"subtext1": {hex: "#5c5f77"},
+		"base":     {hex: "#eff1f5"},
+	}, "red", "yellow", "text", "subtext1", "base")
+
+	report := Contrast("latte", variant)
+
+	expected := []struct {
+		foreground string
+		level      Level
+		aa         bool
+	}{
+		{"text", LevelRequired, true},
+		{"subtext1", LevelRequired, true},
+		{"red", LevelRecommended, true},
+		{"yellow", LevelRecommended, false},
+	}
+	if len(report.Pairs) != len(expected) {
+		t.Fatalf("expected %d pairs, got %+v", len(expected), report.Pairs)
+	}
+
+	for i, want := range expected {
+		pair := report.Pairs[i]
+		if pair.Foreground != want.foreground || pair.Background != "base" || pair.Level != want.level || pair.AA != want.aa {
+			t.Errorf("pair %d: expected %s on base (%s, AA %t), got %+v", i, want.foreground, want.level, want.aa, pair)
+		}
+		if pair.AAA && !pair.AA {
+			t.Errorf("%s: AAA without AA", pair.Foreground)
+		}
+	}
+
+	text := report.Pairs[0]
+	if text.APCA < 75 || !text.Font.Readable {
+		t.Errorf("expected text on base to be readable body text, got Lc %.1f and %+v", text.APCA, text.Font)
+	}
+
+	failures := report.Failures(LevelRecommended)
+	if len(failures) != 1 || failures[0].Foreground != "yellow" {
+		t.Errorf("expected only yellow to fail, got %+v", failures)
+	}
+	if failures := report.Failures(LevelRequired); len(failures) != 0 {
+		t.Errorf("expected no required failures, got %+v", failures)
+	}
+}
@@ -0,0 +1,106 @@
+package accessibility
+
+import (
+	"sort"
+
+	"github.com/openpalettestandard/openpalette/internal/color"
+	"github.com/openpalettestandard/openpalette/internal/types"
+)
+
+const (
+	DefaultDistanceThreshold = 5.0
+	DefaultMetric            = color.CIEDE2000
+)
+
+const VisionNormal = "normal"
+
+type AccentPair struct {
+	A        string  `json:"a"`
+	B        string  `json:"b"`
+	Vision   string  `json:"vision"`
+	Distance float64 `json:"distance"`
+}
+
+type DistinguishReport struct {
+	Variant   string                `json:"variant"`
+	Metric    color.Metric          `json:"metric"`
+	Threshold float64               `json:"threshold"`
+	Closest   map[string]AccentPair `json:"closest"`
+	Flagged   []AccentPair          `json:"flagged"`
+}
+
+func Visions() []string {
+	visions := []string{VisionNormal}
+	for _, deficiency := range color.Deficiencies {
+		visions = append(visions, string(deficiency))
+	}
+	return visions
+}
+
+func accentIDs(variant types.PaletteVariant) []string {
+	var ids []string
+	for id, c := range variant.PaletteColors {
+		if c.Accent {
+			ids = append(ids, id)
+		}
+	}
+	sort.Slice(ids, func(i, j int) bool {
+		a, b := variant.PaletteColors[ids[i]], variant.PaletteColors[ids[j]]
+		if a.Order != b.Order {
+			return a.Order < b.Order
+		}
+		return ids[i] < ids[j]
+	})
+	return ids
+}
+
+func Distinguish(variantID string, variant types.PaletteVariant, metric color.Metric, threshold float64) DistinguishReport {
+	report := DistinguishReport{
+		Variant:   variantID,
+		Metric:    metric,
+		Threshold: threshold,
+		Closest:   make(map[string]AccentPair),
+	}
+
+	ids := accentIDs(variant)
+
+	for _, vision := range Visions() {
+		colors := make([]*color.Color, len(ids))
+		for i, id := range ids {
+			colors[i] = color.NewColor(variant.PaletteColors[id].Hex)
+			if vision != VisionNormal {
+				colors[i] = colors[i].Simulate(color.Deficiency(vision))
+			}
+		}
+
+		for i := 0; i < len(ids); i++ {
+			for j := i + 1; j < len(ids); j++ {
+				pair := AccentPair{
+					A:        ids[i],
+					B:        ids[j],
+					Vision:   vision,
+					Distance: color.Distance(metric, colors[i], colors[j]),
+				}
+
+				if closest, exists := report.Closest[vision]; !exists || pair.Distance < closest.Distance {
+					report.Closest[vision] = pair
+				}
+				if pair.Distance < threshold {
+					report.Flagged = append(report.Flagged, pair)
+				}
+			}
+		}
+	}
+
+	return report
+}
+
+func DistinguishAll(result types.PaletteResult, metric color.Metric, threshold float64) []DistinguishReport {
+	ids := variantIDs(result)
+
+	reports := make([]DistinguishReport, 0, len(ids))
+	for _, id := range ids {
+		reports = append(reports, Distinguish(id, result.Variants[id], metric, threshold))
+	}
+	return reports
+}
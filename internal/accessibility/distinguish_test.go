@@ -0,0 +1,45 @@
+package accessibility
+
+import (
+	"testing"
+
+	"github.com/openpalettestandard/openpalette/internal/color"
+)
+
+func TestDistinguish(t *testing.T) {
+	variant := testVariant(map[string]testColor{
+		"red":     {hex: "#d20f39", accent: true},
+		"crimson": {hex: "#d3123b", accent: true},
+		"blue":    {hex: "#1e66f5", accent: true},
+		"base":    {hex: "#d20f39"},
+	}, "red", "crimson", "blue", "base")
+
+	report := Distinguish("latte", variant, DefaultMetric, DefaultDistanceThreshold)
+
+	if len(report.Closest) != len(Visions()) {
+		t.Errorf("expected a closest pair for each of %v, got %+v", Visions(), report.Closest)
+	}
+	if closest := report.Closest[VisionNormal]; closest.A != "red" || closest.B != "crimson" {
+		t.Errorf("expected red and crimson to be closest, got %+v", closest)
+	}
+
+	flagged := make(map[string]bool)
+	for _, pair := range report.Flagged {
+		if pair.Distance >= DefaultDistanceThreshold {
+			t.Errorf("flagged %+v is not below the threshold", pair)
+		}
+		if pair.A == "base" || pair.B == "base" {
+			t.Errorf("expected only accents to be compared, got %+v", pair)
+		}
+		if pair.Vision == VisionNormal {
+			flagged[pair.A+"/"+pair.B] = true
+		}
+	}
+	if !flagged["red/crimson"] || flagged["red/blue"] || flagged["crimson/blue"] {
+		t.Errorf("expected only red/crimson to be flagged under normal vision, got %v", flagged)
+	}
+
+	if report := Distinguish("latte", variant, color.CIE76, 0); len(report.Flagged) != 0 {
+		t.Errorf("expected nothing to be flagged with a zero threshold, got %+v", report.Flagged)
+	}
+}
@@ -0,0 +1,65 @@
+package color
+
+import "math"
+
+// APCA-W3 0.0.98G-4g constants.
+const (
+	apcaMainTRC = 2.4
+
+	apcaNormBG  = 0.56
+	apcaNormTXT = 0.57
+	apcaRevTXT  = 0.62
+	apcaRevBG   = 0.65
+
+	apcaBlkThrs = 0.022
+	apcaBlkClmp = 1.414
+
+	apcaScaleBoW    = 1.14
+	apcaScaleWoB    = 1.14
+	apcaLoBoWOffset = 0.027
+	apcaLoWoBOffset = 0.027
+	apcaDeltaYMin   = 0.0005
+	apcaLoClip      = 0.1
+)
+
+func (c *Color) apcaLuminance() float64 {
+	coords := c.ToSRGBGamut()
+
+	return 0.2126729*math.Pow(coords[0], apcaMainTRC) +
+		0.7151522*math.Pow(coords[1], apcaMainTRC) +
+		0.0721750*math.Pow(coords[2], apcaMainTRC)
+}
+
+// APCAContrast returns the APCA lightness contrast (Lc) of text on background.
+// Positive values are dark text on a light background, negative values are
+// light text on a dark background.
+func APCAContrast(text, background *Color) float64 {
+	txtY := text.apcaLuminance()
+	bgY := background.apcaLuminance()
+
+	if txtY <= apcaBlkThrs {
+		txtY += math.Pow(apcaBlkThrs-txtY, apcaBlkClmp)
+	}
+	if bgY <= apcaBlkThrs {
+		bgY += math.Pow(apcaBlkThrs-bgY, apcaBlkClmp)
+	}
+
+	if math.Abs(bgY-txtY) < apcaDeltaYMin {
+		return 0
+	}
+
+	var output float64
+	if bgY > txtY {
+		sapc := (math.Pow(bgY, apcaNormBG) - math.Pow(txtY, apcaNormTXT)) * apcaScaleBoW
+		if sapc >= apcaLoClip {
+			output = sapc - apcaLoBoWOffset
+		}
+	} else {
+		sapc := (math.Pow(bgY, apcaRevBG) - math.Pow(txtY, apcaRevTXT)) * apcaScaleWoB
+		if sapc <= -apcaLoClip {
+			output = sapc + apcaLoWoBOffset
+		}
+	}
+
+	return output * 100
+}
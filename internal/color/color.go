@@ -9,58 +9,112 @@ import (
 	"github.com/openpalettestandard/openpalette/internal/types"
 )
 
+type Space string
+
+const (
+	SpaceSRGB  Space = "srgb"
+	SpaceLCH   Space = "lch"
+	SpaceOKLCH Space = "oklch"
+)
+
 type Color struct {
 	hex   string
 	lch   [3]float64
-	isLCH bool
+	oklch [3]float64
+	space Space
+	alpha float64
 }
 
 func NewColor(hex string) *Color {
-	return &Color{
+	c := &Color{
 		hex:   strings.TrimPrefix(hex, "#"),
-		isLCH: false,
+		space: SpaceSRGB,
+		alpha: 1,
+	}
+
+	if len(c.hex) == 8 {
+		a, _ := strconv.ParseInt(c.hex[6:8], 16, 0)
+		c.alpha = float64(a) / 255
+		c.hex = c.hex[:6]
 	}
+
+	return c
 }
 
-func (c *Color) Clone() *Color {
-	clone := &Color{
-		hex:   c.hex,
-		isLCH: c.isLCH,
+func NewLCH(l, ch, h float64) *Color {
+	return &Color{
+		lch:   [3]float64{l, ch, h},
+		space: SpaceLCH,
+		alpha: 1,
 	}
-	if c.isLCH {
-		clone.lch = c.lch
+}
+
+func NewOKLCH(l, c, h float64) *Color {
+	return &Color{
+		oklch: [3]float64{l, c, h},
+		space: SpaceOKLCH,
+		alpha: 1,
+	}
+}
+
+func (c *Color) Alpha() float64 {
+	return c.alpha
+}
+
+func (c *Color) SetAlpha(alpha float64) {
+	c.alpha = clampFloat(alpha, 0, 1)
+}
+
+func (c *Color) alphaHex() string {
+	if c.alpha >= 1 {
+		return ""
 	}
-	return clone
+	return fmt.Sprintf("%02x", int(math.Round(c.alpha*255)))
+}
+
+func (c *Color) Clone() *Color {
+	clone := *c
+	return &clone
 }
 
 func (c *Color) ToString() string {
-	if c.isLCH {
-		return c.lchToHex()
+	if c.space == SpaceSRGB {
+		return "#" + c.hex + c.alphaHex()
 	}
-	return "#" + c.hex
+	return c.srgbToHex() + c.alphaHex()
 }
 
 func (c *Color) ToSRGBGamut() [3]float64 {
-	var r, g, b float64
+	r, g, b := c.gamutMap()
+	return [3]float64{r, g, b}
+}
 
-	if c.isLCH {
-		r, g, b = c.lchToSRGB()
-	} else {
-		r, g, b = c.hexToSRGB()
+func (c *Color) toSRGB() (float64, float64, float64) {
+	switch c.space {
+	case SpaceLCH:
+		return c.lchToSRGB()
+	case SpaceOKLCH:
+		return c.oklchToSRGB()
+	default:
+		return c.hexToSRGB()
 	}
+}
 
-	r = clampFloat(r, 0, 1)
-	g = clampFloat(g, 0, 1)
-	b = clampFloat(b, 0, 1)
-
-	return [3]float64{r, g, b}
+func (c *Color) toLinearRGB() (float64, float64, float64) {
+	r, g, b := c.toSRGB()
+	return srgbToLinear(r), srgbToLinear(g), srgbToLinear(b)
 }
 
 func (c *Color) GetLCH() *LCHColor {
-	if !c.isLCH {
+	switch c.space {
+	case SpaceSRGB:
 		c.lch = c.hexToLCH()
-		c.isLCH = true
+	case SpaceOKLCH:
+		x, y, z := linearRGBToXYZ(c.toLinearRGB())
+		l, a, b := xyzToLab(x, y, z)
+		c.lch[0], c.lch[1], c.lch[2] = labToLCH(l, a, b)
 	}
+	c.space = SpaceLCH
 
 	return &LCHColor{color: c}
 }
@@ -210,8 +264,8 @@ func (c *Color) lchToSRGB() (float64, float64, float64) {
 	return r, g, b
 }
 
-func (c *Color) lchToHex() string {
-	r, g, b := c.lchToSRGB()
+func (c *Color) srgbToHex() string {
+	r, g, b := c.gamutMap()
 
 	rInt := int(math.Round(clampFloat(r, 0, 1) * 255))
 	gInt := int(math.Round(clampFloat(g, 0, 1) * 255))
@@ -341,3 +395,24 @@ func lchToLab(l, c, h float64) (float64, float64, float64) {
 
 	return l, a, b
 }
+
+func (c *Color) RelativeLuminance() float64 {
+	coords := c.ToSRGBGamut()
+
+	r := srgbToLinear(coords[0])
+	g := srgbToLinear(coords[1])
+	b := srgbToLinear(coords[2])
+
+	return 0.2126*r + 0.7152*g + 0.0722*b
+}
+
+func ContrastRatio(a, b *Color) float64 {
+	la := a.RelativeLuminance()
+	lb := b.RelativeLuminance()
+
+	if la < lb {
+		la, lb = lb, la
+	}
+
+	return (la + 0.05) / (lb + 0.05)
+}
@@ -4,13 +4,15 @@ import (
 	"fmt"
 	"math"
 	"testing"
+
+	"github.com/openpalettestandard/openpalette/internal/types"
 )
 
 type ColorTestCase struct {
 	name        string
 	hex         string
-	expectedRGB RGB
-	expectedHSL HSL
+	expectedRGB types.RGB
+	expectedHSL types.HSL
 }
 
 func getColorTestCases() []ColorTestCase {
@@ -18,26 +20,26 @@ func getColorTestCases() []ColorTestCase {
 		{
 			name:        "Latte Rosewater",
 			hex:         "#dc8a78",
-			expectedRGB: RGB{R: 220, G: 138, B: 120},
-			expectedHSL: HSL{H: 10.799999999999995, S: 0.5882352941176472, L: 0.6666666666666667},
+			expectedRGB: types.RGB{R: 220, G: 138, B: 120},
+			expectedHSL: types.HSL{H: 10.799999999999995, S: 0.5882352941176472, L: 0.6666666666666667},
 		},
 		{
 			name:        "Latte Red",
 			hex:         "#d20f39",
-			expectedRGB: RGB{R: 210, G: 15, B: 57},
-			expectedHSL: HSL{H: 347.0769230769231, S: 0.8666666666666666, L: 0.4411764705882353},
+			expectedRGB: types.RGB{R: 210, G: 15, B: 57},
+			expectedHSL: types.HSL{H: 347.0769230769231, S: 0.8666666666666666, L: 0.4411764705882353},
 		},
 		{
 			name:        "Latte Blue",
 			hex:         "#1e66f5",
-			expectedRGB: RGB{R: 30, G: 102, B: 245},
-			expectedHSL: HSL{H: 219.90697674418607, S: 0.9148936170212768, L: 0.5392156862745098},
+			expectedRGB: types.RGB{R: 30, G: 102, B: 245},
+			expectedHSL: types.HSL{H: 219.90697674418607, S: 0.9148936170212768, L: 0.5392156862745098},
 		},
 		{
 			name:        "Mocha Text",
 			hex:         "#cdd6f4",
-			expectedRGB: RGB{R: 205, G: 214, B: 244},
-			expectedHSL: HSL{H: 226.15384615384616, S: 0.6393442622950825, L: 0.8803921568627451},
+			expectedRGB: types.RGB{R: 205, G: 214, B: 244},
+			expectedHSL: types.HSL{H: 226.15384615384616, S: 0.6393442622950825, L: 0.8803921568627451},
 		},
 	}
 }
@@ -86,7 +88,7 @@ func TestColorConversions(t *testing.T) {
 			color := NewColor(tc.hex)
 
 			coords := color.ToSRGBGamut()
-			actualRGB := RGB{
+			actualRGB := types.RGB{
 				R: int(math.Round(coords[0] * 255)),
 				G: int(math.Round(coords[1] * 255)),
 				B: int(math.Round(coords[2] * 255)),
@@ -97,7 +99,7 @@ func TestColorConversions(t *testing.T) {
 					tc.name, tc.expectedRGB, actualRGB)
 			}
 
-			actualHSL := TinycolorHSL(tc.hex)
+			actualHSL := TinyColorHSL(tc.hex)
 
 			if !floatEqual(actualHSL.H, tc.expectedHSL.H, 0.0001) ||
 				!floatEqual(actualHSL.S, tc.expectedHSL.S, 0.0001) ||
@@ -151,13 +153,341 @@ func TestSingleColor(t *testing.T) {
 	fmt.Printf("ToString: %s\n", color.ToString())
 
 	coords := color.ToSRGBGamut()
-	actualRGB := RGB{
+	actualRGB := types.RGB{
 		R: int(math.Round(coords[0] * 255)),
 		G: int(math.Round(coords[1] * 255)),
 		B: int(math.Round(coords[2] * 255)),
 	}
 	fmt.Printf("RGB: %+v\n", actualRGB)
 
-	actualHSL := TinycolorHSL(hex)
+	actualHSL := TinyColorHSL(hex)
 	fmt.Printf("HSL: H=%.6f S=%.6f L=%.6f\n", actualHSL.H, actualHSL.S, actualHSL.L)
 }
+
+func TestContrastRatio(t *testing.T) {
+	testCases := []struct {
+		name       string
+		foreground string
+		background string
+		expected   float64
+	}{
+		{name: "Black on white", foreground: "#000000", background: "#ffffff", expected: 21},
+		{name: "White on white", foreground: "#ffffff", background: "#ffffff", expected: 1},
+		{name: "Grey on white", foreground: "#777777", background: "#ffffff", expected: 4.478},
+		{name: "Latte text on base", foreground: "#4c4f69", background: "#eff1f5", expected: 7.06},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ratio := ContrastRatio(NewColor(tc.foreground), NewColor(tc.background))
+			if !floatEqual(ratio, tc.expected, 0.01) {
+				t.Errorf("contrast mismatch for %s: expected %.3f, got %.3f", tc.name, tc.expected, ratio)
+			}
+
+			reversed := ContrastRatio(NewColor(tc.background), NewColor(tc.foreground))
+			if !floatEqual(ratio, reversed, 1e-9) {
+				t.Errorf("contrast is not symmetric for %s: %.6f vs %.6f", tc.name, ratio, reversed)
+			}
+		})
+	}
+}
+
+func TestAPCAContrast(t *testing.T) {
+	// Reference values published with the apca-w3 0.0.98G-4g implementation.
+	testCases := []struct {
+		text       string
+		background string
+		expected   float64
+	}{
+		{text: "#888888", background: "#ffffff", expected: 63.056469930209424},
+		{text: "#ffffff", background: "#888888", expected: -68.54146436644962},
+		{text: "#000000", background: "#aaaaaa", expected: 58.146262578561334},
+		{text: "#aaaaaa", background: "#000000", expected: -56.24113336839742},
+		{text: "#112233", background: "#ddeeff", expected: 91.66830811481631},
+		{text: "#ddeeff", background: "#112233", expected: -93.06770049484275},
+		{text: "#777777", background: "#777777", expected: 0},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.text+" on "+tc.background, func(t *testing.T) {
+			lc := APCAContrast(NewColor(tc.text), NewColor(tc.background))
+			if !floatEqual(lc, tc.expected, 1e-9) {
+				t.Errorf("APCA mismatch: expected %v, got %v", tc.expected, lc)
+			}
+		})
+	}
+}
+
+func TestSimulatePreservesNeutrals(t *testing.T) {
+	for _, deficiency := range Deficiencies {
+		for _, hex := range []string{"#000000", "#808080", "#ffffff"} {
+			simulated := NewColor(hex).Simulate(deficiency)
+			if distance := DeltaE76(NewColor(hex), simulated); distance > 1 {
+				t.Errorf("%s changed neutral %s to %s (distance %.2f)", deficiency, hex, simulated.ToString(), distance)
+			}
+		}
+	}
+
+	red := NewColor("#d20f39")
+	if distance := DeltaE76(red, red.Simulate(Protanopia)); distance < 10 {
+		t.Errorf("expected protanopia to visibly change %s, distance %.2f", red.ToString(), distance)
+	}
+}
+
+func TestDeltaE2000(t *testing.T) {
+	// Test pairs from Sharma, Wu & Dalal (2005).
+	testCases := []struct {
+		lab1     [3]float64
+		lab2     [3]float64
+		expected float64
+	}{
+		{lab1: [3]float64{50, 2.6772, -79.7751}, lab2: [3]float64{50, 0, -82.7485}, expected: 2.0425},
+		{lab1: [3]float64{50, 3.1571, -77.2803}, lab2: [3]float64{50, 0, -82.7485}, expected: 2.8615},
+		{lab1: [3]float64{50, 0, 0}, lab2: [3]float64{50, -1, 2}, expected: 2.3669},
+		{lab1: [3]float64{50, 2.49, -0.001}, lab2: [3]float64{50, -2.49, 0.0011}, expected: 7.2195},
+		{lab1: [3]float64{50, 2.5, 0}, lab2: [3]float64{73, 25, -18}, expected: 27.1492},
+		{lab1: [3]float64{60.2574, -34.0099, 36.2677}, lab2: [3]float64{60.4626, -34.1751, 39.4387}, expected: 1.2644},
+		{lab1: [3]float64{2.0776, 0.0795, -1.135}, lab2: [3]float64{0.9033, -0.0636, -0.5514}, expected: 0.9082},
+	}
+
+	for _, tc := range testCases {
+		actual := deltaE2000Lab(tc.lab1, tc.lab2)
+		if !floatEqual(actual, tc.expected, 0.0001) {
+			t.Errorf("ΔE2000 mismatch for %v / %v: expected %.4f, got %.4f", tc.lab1, tc.lab2, tc.expected, actual)
+		}
+
+		reversed := deltaE2000Lab(tc.lab2, tc.lab1)
+		if !floatEqual(actual, reversed, 1e-9) {
+			t.Errorf("ΔE2000 is not symmetric for %v / %v", tc.lab1, tc.lab2)
+		}
+	}
+}
+
+func TestDeltaE76And94(t *testing.T) {
+	a, b := NewColor("#dc8a78"), NewColor("#dd7878")
+
+	if d := DeltaE76(a, a); d != 0 {
+		t.Errorf("expected zero ΔE76 for identical colors, got %v", d)
+	}
+	if d76, d94 := DeltaE76(a, b), DeltaE94(a, b); d94 > d76 {
+		t.Errorf("expected ΔE94 (%.4f) to be no larger than ΔE76 (%.4f)", d94, d76)
+	}
+}
+
+func TestOKLCH(t *testing.T) {
+	testCases := []struct {
+		hex      string
+		expected [3]float64
+	}{
+		{hex: "#ffffff", expected: [3]float64{1, 0, 0}},
+		{hex: "#ff0000", expected: [3]float64{0.627955, 0.257683, 29.2339}},
+		{hex: "#0000ff", expected: [3]float64{0.452014, 0.313214, 264.052}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.hex, func(t *testing.T) {
+			actual := NewColor(tc.hex).OKLCH()
+
+			if !floatEqual(actual[0], tc.expected[0], 0.0001) || !floatEqual(actual[1], tc.expected[1], 0.0001) {
+				t.Errorf("OKLCH mismatch for %s: expected %v, got %v", tc.hex, tc.expected, actual)
+			}
+			if tc.expected[1] > 0 && !floatEqual(actual[2], tc.expected[2], 0.01) {
+				t.Errorf("OKLCH hue mismatch for %s: expected %v, got %v", tc.hex, tc.expected[2], actual[2])
+			}
+
+			roundTrip := NewColor(tc.hex)
+			roundTrip.GetOKLCH()
+			if roundTrip.ToString() != tc.hex {
+				t.Errorf("OKLCH round trip changed %s to %s", tc.hex, roundTrip.ToString())
+			}
+		})
+	}
+}
+
+func TestGamutMapping(t *testing.T) {
+	inGamut := NewColor("#fe640b")
+	if !inGamut.InGamut() || inGamut.ToString() != "#fe640b" {
+		t.Errorf("expected in-gamut color to be unchanged, got %s", inGamut.ToString())
+	}
+
+	saturated := NewColor("#fe640b")
+	lch := saturated.GetLCH()
+	lch.SetC(lch.C() + 40)
+
+	if saturated.InGamut() {
+		t.Fatalf("expected %v to be out of gamut", lch)
+	}
+
+	originalHue := saturated.OKLCH()[2]
+	mapped := NewColor(saturated.ToString())
+	if !mapped.InGamut() {
+		t.Errorf("mapped color %s is not in gamut", mapped.ToString())
+	}
+	if hueShift := math.Abs(mapped.OKLCH()[2] - originalHue); hueShift > 3 {
+		t.Errorf("gamut mapping shifted hue by %.2f degrees", hueShift)
+	}
+
+	if NewOKLCH(1.2, 0.1, 30).ToString() != "#ffffff" {
+		t.Errorf("expected lightness above 1 to map to white")
+	}
+	if NewOKLCH(-0.1, 0.1, 30).ToString() != "#000000" {
+		t.Errorf("expected lightness below 0 to map to black")
+	}
+}
+
+func TestDisplayP3(t *testing.T) {
+	// sRGB red expressed in Display P3, per CSS Color 4.
+	p3 := NewColor("#ff0000").DisplayP3()
+	expected := [3]float64{0.9175, 0.2003, 0.1386}
+	for i := range p3 {
+		if !floatEqual(p3[i], expected[i], 0.001) {
+			t.Errorf("Display P3 mismatch: expected %v, got %v", expected, p3)
+			break
+		}
+	}
+
+	white := NewColor("#ffffff")
+	for _, coords := range [][3]float64{white.DisplayP3(), white.Rec2020()} {
+		for _, v := range coords {
+			if !floatEqual(v, 1, 0.0005) {
+				t.Errorf("expected white to map to 1,1,1, got %v", coords)
+			}
+		}
+	}
+
+	c, space, err := ParseColorFunction("color(display-p3 1 0 0)")
+	if err != nil {
+		t.Fatalf("parsing display-p3 color: %v", err)
+	}
+	if space != RGBSpaceDisplayP3 {
+		t.Errorf("expected display-p3 space, got %s", space)
+	}
+	if c.InGamut() {
+		t.Errorf("expected P3 red to be outside sRGB")
+	}
+	if roundTrip := c.DisplayP3(); !floatEqual(roundTrip[0], 1, 0.0005) || !floatEqual(roundTrip[1], 0, 0.0005) {
+		t.Errorf("P3 round trip mismatch: %v", roundTrip)
+	}
+
+	if _, _, err := ParseColorFunction("color(display-p3 1 0)"); err == nil {
+		t.Errorf("expected an error for a missing component")
+	}
+}
+
+func TestParse(t *testing.T) {
+	testCases := []struct {
+		input    string
+		expected string
+	}{
+		{input: "#dc8a78", expected: "#dc8a78"},
+		{input: "#DC8A78", expected: "#dc8a78"},
+		{input: "#9de", expected: "#99ddee"},
+		{input: "#9def", expected: "#99ddee"},
+		{input: "#dc8a78ff", expected: "#dc8a78"},
+		{input: "rgb(220, 138, 120)", expected: "#dc8a78"},
+		{input: "rgba(220, 138, 120, 0.5)", expected: "#dc8a7880"},
+		{input: "rgb(220 138 120 / 50%)", expected: "#dc8a7880"},
+		{input: "rgb(100% 0% 50%)", expected: "#ff0080"},
+		{input: "hsl(115, 54%, 76%)", expected: "#a6e3a1"},
+		{input: "hsl(0.5turn 100% 50%)", expected: "#00ffff"},
+		{input: "hwb(120 0% 0%)", expected: "#00ff00"},
+		{input: "hwb(0 60% 60%)", expected: "#808080"},
+		{input: "lab(100 0 0)", expected: "#ffffff"},
+		{input: "lch(0% 0 0)", expected: "#000000"},
+		{input: "oklab(0.627955 0.224863 0.125846)", expected: "#ff0000"},
+		{input: "oklch(62.7955% 0.257683 29.2339deg)", expected: "#ff0000"},
+		{input: "color(srgb 1 0 0)", expected: "#ff0000"},
+		{input: "RebeccaPurple", expected: "#663399"},
+		{input: "  skyblue ", expected: "#87ceeb"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.input, func(t *testing.T) {
+			c, err := Parse(tc.input)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if c.ToString() != tc.expected {
+				t.Errorf("expected %s, got %s", tc.expected, c.ToString())
+			}
+		})
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	for _, input := range []string{
+		"",
+		"#12",
+		"#12345",
+		"#gggggg",
+		"notacolor",
+		"rgb(1, 2)",
+		"rgb(1 2 3",
+		"hsl(red 50% 50%)",
+		"rgb(1 2 3 /)",
+		"cmyk(0 0 0 0)",
+		"color(prophoto-rgb 1 0 0)",
+	} {
+		if _, err := Parse(input); err == nil {
+			t.Errorf("expected an error for %q", input)
+		}
+	}
+}
+
+func TestAlpha(t *testing.T) {
+	testCases := []struct {
+		input    string
+		expected float64
+	}{
+		{input: "#dc8a78", expected: 1},
+		{input: "#dc8a7866", expected: 0.4},
+		{input: "#9de8", expected: 0.5333},
+		{input: "rgb(220 138 120 / 0)", expected: 0},
+		{input: "hsl(10.8 58.8% 66.7% / 40%)", expected: 0.4},
+		{input: "oklch(0.7 0.1 33 / 0.25)", expected: 0.25},
+		{input: "color(display-p3 1 0 0 / 0.5)", expected: 0.5},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.input, func(t *testing.T) {
+			c, err := Parse(tc.input)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !floatEqual(c.Alpha(), tc.expected, 0.0001) {
+				t.Errorf("expected alpha %v, got %v", tc.expected, c.Alpha())
+			}
+
+			converted := c.Clone()
+			converted.GetOKLCH().SetC(converted.GetOKLCH().C() * 0.5)
+			converted.GetLCH()
+			if !floatEqual(converted.Alpha(), c.Alpha(), 1e-9) {
+				t.Errorf("alpha changed across conversions: %v -> %v", c.Alpha(), converted.Alpha())
+			}
+
+			if roundTrip := NewColor(c.ToString()); !floatEqual(roundTrip.Alpha(), c.Alpha(), 0.5/255) {
+				t.Errorf("alpha lost in hex round trip %s: %v", c.ToString(), roundTrip.Alpha())
+			}
+		})
+	}
+}
+
+func TestMix(t *testing.T) {
+	a, b := NewColor("#1e1e2e"), NewColor("#cdd6f4")
+
+	for _, space := range []Space{SpaceOKLCH, SpaceLCH} {
+		if got := Mix(a, b, 0, space).ToString(); got != "#1e1e2e" {
+			t.Errorf("%s: Mix at 0 = %s, want #1e1e2e", space, got)
+		}
+		if got := Mix(a, b, 1, space).ToString(); got != "#cdd6f4" {
+			t.Errorf("%s: Mix at 1 = %s, want #cdd6f4", space, got)
+		}
+	}
+
+	// Mixing with a gray keeps the hue of the chromatic color.
+	red := NewColor("#ff0000")
+	mixed := Mix(NewColor("#808080"), red, 0.5, SpaceOKLCH).OKLCH()
+	if math.Abs(mixed[2]-red.OKLCH()[2]) > 0.01 {
+		t.Errorf("expected hue %.2f from red, got %.2f", red.OKLCH()[2], mixed[2])
+	}
+}
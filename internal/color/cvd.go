@@ -0,0 +1,58 @@
+package color
+
+import (
+	"fmt"
+	"math"
+)
+
+type Deficiency string
+
+const (
+	Protanopia   Deficiency = "protanopia"
+	Deuteranopia Deficiency = "deuteranopia"
+	Tritanopia   Deficiency = "tritanopia"
+)
+
+var Deficiencies = []Deficiency{Protanopia, Deuteranopia, Tritanopia}
+
+// Machado, Oliveira & Fernandes (2009) simulation matrices at severity 1.0,
+// applied to linear sRGB.
+var cvdMatrices = map[Deficiency][3][3]float64{
+	Protanopia: {
+		{0.152286, 1.052583, -0.204868},
+		{0.114503, 0.786281, 0.099216},
+		{-0.003882, -0.048116, 1.051998},
+	},
+	Deuteranopia: {
+		{0.367322, 0.860646, -0.227968},
+		{0.280085, 0.672501, 0.047413},
+		{-0.011820, 0.042940, 0.968881},
+	},
+	Tritanopia: {
+		{1.255528, -0.076749, -0.178779},
+		{-0.078411, 0.930809, 0.147602},
+		{0.004733, 0.691367, 0.303900},
+	},
+}
+
+func (c *Color) Simulate(deficiency Deficiency) *Color {
+	m, exists := cvdMatrices[deficiency]
+	if !exists {
+		return c.Clone()
+	}
+
+	coords := c.ToSRGBGamut()
+	r := srgbToLinear(coords[0])
+	g := srgbToLinear(coords[1])
+	b := srgbToLinear(coords[2])
+
+	var out [3]int
+	for i := range out {
+		v := m[i][0]*r + m[i][1]*g + m[i][2]*b
+		out[i] = int(math.Round(clampFloat(linearToSRGB(clampFloat(v, 0, 1)), 0, 1) * 255))
+	}
+
+	simulated := NewColor(fmt.Sprintf("#%02x%02x%02x", out[0], out[1], out[2]))
+	simulated.SetAlpha(c.alpha)
+	return simulated
+}
@@ -0,0 +1,155 @@
+package color
+
+import "math"
+
+type Metric string
+
+const (
+	CIE76     Metric = "cie76"
+	CIE94     Metric = "cie94"
+	CIEDE2000 Metric = "ciede2000"
+)
+
+var Metrics = []Metric{CIE76, CIE94, CIEDE2000}
+
+func (c *Color) Lab() [3]float64 {
+	if c.space == SpaceLCH {
+		l, a, b := lchToLab(c.lch[0], c.lch[1], c.lch[2])
+		return [3]float64{l, a, b}
+	}
+
+	x, y, z := linearRGBToXYZ(c.toLinearRGB())
+	l, labA, labB := xyzToLab(x, y, z)
+
+	return [3]float64{l, labA, labB}
+}
+
+func Distance(metric Metric, a, b *Color) float64 {
+	switch metric {
+	case CIE76:
+		return DeltaE76(a, b)
+	case CIE94:
+		return DeltaE94(a, b)
+	default:
+		return DeltaE2000(a, b)
+	}
+}
+
+func DeltaE76(a, b *Color) float64 {
+	lab1, lab2 := a.Lab(), b.Lab()
+
+	dl := lab1[0] - lab2[0]
+	da := lab1[1] - lab2[1]
+	db := lab1[2] - lab2[2]
+
+	return math.Sqrt(dl*dl + da*da + db*db)
+}
+
+// DeltaE94 uses the graphic arts weighting (kL = 1, K1 = 0.045, K2 = 0.015)
+// with a as the reference color.
+func DeltaE94(a, b *Color) float64 {
+	lab1, lab2 := a.Lab(), b.Lab()
+
+	c1 := math.Hypot(lab1[1], lab1[2])
+	c2 := math.Hypot(lab2[1], lab2[2])
+
+	dl := lab1[0] - lab2[0]
+	dc := c1 - c2
+	da := lab1[1] - lab2[1]
+	db := lab1[2] - lab2[2]
+	dh2 := math.Max(0, da*da+db*db-dc*dc)
+
+	sc := 1 + 0.045*c1
+	sh := 1 + 0.015*c1
+
+	return math.Sqrt(dl*dl + (dc/sc)*(dc/sc) + dh2/(sh*sh))
+}
+
+func DeltaE2000(a, b *Color) float64 {
+	lab1, lab2 := a.Lab(), b.Lab()
+	return deltaE2000Lab(lab1, lab2)
+}
+
+func deltaE2000Lab(lab1, lab2 [3]float64) float64 {
+	const pow25_7 = 6103515625.0 // 25^7
+
+	l1, a1, b1 := lab1[0], lab1[1], lab1[2]
+	l2, a2, b2 := lab2[0], lab2[1], lab2[2]
+
+	c1 := math.Hypot(a1, b1)
+	c2 := math.Hypot(a2, b2)
+	cMean7 := math.Pow((c1+c2)/2, 7)
+	g := 0.5 * (1 - math.Sqrt(cMean7/(cMean7+pow25_7)))
+
+	a1p := a1 * (1 + g)
+	a2p := a2 * (1 + g)
+	c1p := math.Hypot(a1p, b1)
+	c2p := math.Hypot(a2p, b2)
+
+	h1p := hueDegrees(a1p, b1)
+	h2p := hueDegrees(a2p, b2)
+
+	dlp := l2 - l1
+	dcp := c2p - c1p
+
+	var dhp float64
+	if c1p*c2p != 0 {
+		dhp = h2p - h1p
+		if dhp > 180 {
+			dhp -= 360
+		} else if dhp < -180 {
+			dhp += 360
+		}
+	}
+	dHp := 2 * math.Sqrt(c1p*c2p) * math.Sin(radians(dhp/2))
+
+	lMean := (l1 + l2) / 2
+	cMeanP := (c1p + c2p) / 2
+
+	hMean := h1p + h2p
+	if c1p*c2p != 0 {
+		if math.Abs(h1p-h2p) > 180 {
+			if hMean < 360 {
+				hMean += 360
+			} else {
+				hMean -= 360
+			}
+		}
+		hMean /= 2
+	}
+
+	t := 1 - 0.17*math.Cos(radians(hMean-30)) +
+		0.24*math.Cos(radians(2*hMean)) +
+		0.32*math.Cos(radians(3*hMean+6)) -
+		0.20*math.Cos(radians(4*hMean-63))
+
+	dTheta := 30 * math.Exp(-((hMean-275)/25)*((hMean-275)/25))
+	cMeanP7 := math.Pow(cMeanP, 7)
+	rc := 2 * math.Sqrt(cMeanP7/(cMeanP7+pow25_7))
+
+	sl := 1 + (0.015*(lMean-50)*(lMean-50))/math.Sqrt(20+(lMean-50)*(lMean-50))
+	sc := 1 + 0.045*cMeanP
+	sh := 1 + 0.015*cMeanP*t
+	rt := -math.Sin(radians(2*dTheta)) * rc
+
+	lTerm := dlp / sl
+	cTerm := dcp / sc
+	hTerm := dHp / sh
+
+	return math.Sqrt(lTerm*lTerm + cTerm*cTerm + hTerm*hTerm + rt*cTerm*hTerm)
+}
+
+func hueDegrees(a, b float64) float64 {
+	if a == 0 && b == 0 {
+		return 0
+	}
+	h := math.Atan2(b, a) * 180 / math.Pi
+	if h < 0 {
+		h += 360
+	}
+	return h
+}
+
+func radians(degrees float64) float64 {
+	return degrees * math.Pi / 180
+}
@@ -0,0 +1,79 @@
+package color
+
+import "math"
+
+const (
+	gamutJND     = 0.02
+	gamutEpsilon = 0.0001
+)
+
+func (c *Color) InGamut() bool {
+	r, g, b := c.toSRGB()
+	return inUnitRange(r) && inUnitRange(g) && inUnitRange(b)
+}
+
+func inUnitRange(v float64) bool {
+	return v >= -gamutEpsilon && v <= 1+gamutEpsilon
+}
+
+// gamutMap implements the CSS Color 4 gamut mapping algorithm: chroma is
+// reduced in OKLCH until the clipped color is within a ΔEOK of gamutJND.
+func (c *Color) gamutMap() (float64, float64, float64) {
+	r, g, b := c.toSRGB()
+	if inUnitRange(r) && inUnitRange(g) && inUnitRange(b) {
+		return clampFloat(r, 0, 1), clampFloat(g, 0, 1), clampFloat(b, 0, 1)
+	}
+
+	origin := c.OKLCH()
+	if origin[0] >= 1 {
+		return 1, 1, 1
+	}
+	if origin[0] <= 0 {
+		return 0, 0, 0
+	}
+
+	current := NewOKLCH(origin[0], origin[1], origin[2])
+	clipped := clipSRGB(current)
+	if deltaEOK(clipped, current) < gamutJND {
+		return clipped[0], clipped[1], clipped[2]
+	}
+
+	min, max := 0.0, origin[1]
+	minInGamut := true
+
+	for max-min > gamutEpsilon {
+		chroma := (min + max) / 2
+		current.oklch[1] = chroma
+
+		if minInGamut && current.InGamut() {
+			min = chroma
+			continue
+		}
+
+		clipped = clipSRGB(current)
+		e := deltaEOK(clipped, current)
+		if e < gamutJND {
+			if gamutJND-e < gamutEpsilon {
+				break
+			}
+			minInGamut = false
+			min = chroma
+		} else {
+			max = chroma
+		}
+	}
+
+	return clipped[0], clipped[1], clipped[2]
+}
+
+func clipSRGB(c *Color) [3]float64 {
+	r, g, b := c.toSRGB()
+	return [3]float64{clampFloat(r, 0, 1), clampFloat(g, 0, 1), clampFloat(b, 0, 1)}
+}
+
+func deltaEOK(srgb [3]float64, c *Color) float64 {
+	l1, a1, b1 := linearRGBToOKLab(srgbToLinear(srgb[0]), srgbToLinear(srgb[1]), srgbToLinear(srgb[2]))
+	lab2 := c.OKLab()
+
+	return math.Sqrt((l1-lab2[0])*(l1-lab2[0]) + (a1-lab2[1])*(a1-lab2[1]) + (b1-lab2[2])*(b1-lab2[2]))
+}
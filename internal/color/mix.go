@@ -0,0 +1,54 @@
+package color
+
+import "math"
+
+// Mix interpolates from a to b in the given polar space (SpaceLCH or
+// SpaceOKLCH), taking the shorter way around the hue circle. Values of t
+// outside 0-1 extrapolate lightness and chroma along the same line.
+func Mix(a, b *Color, t float64, space Space) *Color {
+	var from, to [3]float64
+	achromatic := 0.0004
+	if space == SpaceLCH {
+		from, to = a.Clone().lchCoords(), b.Clone().lchCoords()
+		achromatic = 0.1
+	} else {
+		from, to = a.OKLCH(), b.OKLCH()
+	}
+
+	l := from[0] + (to[0]-from[0])*t
+	ch := math.Max(0, from[1]+(to[1]-from[1])*t)
+	h := mixHue(from, to, t, achromatic)
+
+	var mixed *Color
+	if space == SpaceLCH {
+		mixed = NewLCH(clampFloat(l, 0, 100), ch, h)
+	} else {
+		mixed = NewOKLCH(clampFloat(l, 0, 1), ch, h)
+	}
+	mixed.SetAlpha(a.alpha + (b.alpha-a.alpha)*t)
+
+	return mixed
+}
+
+func (c *Color) lchCoords() [3]float64 {
+	lch := c.GetLCH()
+	return [3]float64{lch.L(), lch.C(), lch.H()}
+}
+
+// mixHue treats the hue of a near-achromatic color as missing, so that mixing
+// with a gray keeps the hue of the other color. Hue is never extrapolated.
+func mixHue(from, to [3]float64, t, achromatic float64) float64 {
+	switch {
+	case from[1] < achromatic && to[1] >= achromatic:
+		return to[2]
+	case to[1] < achromatic:
+		return from[2]
+	}
+
+	delta := math.Mod(to[2]-from[2]+540, 360) - 180
+	h := math.Mod(from[2]+delta*clampFloat(t, 0, 1), 360)
+	if h < 0 {
+		h += 360
+	}
+	return h
+}
@@ -0,0 +1,104 @@
+package color
+
+import "math"
+
+func (c *Color) GetOKLCH() *OKLCHColor {
+	if c.space != SpaceOKLCH {
+		l, a, b := linearRGBToOKLab(c.toLinearRGB())
+		c.oklch[0], c.oklch[1], c.oklch[2] = oklabToOKLCH(l, a, b)
+		c.space = SpaceOKLCH
+	}
+
+	return &OKLCHColor{color: c}
+}
+
+type OKLCHColor struct {
+	color *Color
+}
+
+func (oklch *OKLCHColor) L() float64 {
+	return oklch.color.oklch[0]
+}
+
+func (oklch *OKLCHColor) SetL(value float64) {
+	oklch.color.oklch[0] = value
+}
+
+func (oklch *OKLCHColor) C() float64 {
+	return oklch.color.oklch[1]
+}
+
+func (oklch *OKLCHColor) SetC(value float64) {
+	oklch.color.oklch[1] = value
+}
+
+func (oklch *OKLCHColor) H() float64 {
+	return oklch.color.oklch[2]
+}
+
+func (oklch *OKLCHColor) SetH(value float64) {
+	oklch.color.oklch[2] = value
+}
+
+func NewOKLab(l, a, b float64) *Color {
+	l, c, h := oklabToOKLCH(l, a, b)
+	return NewOKLCH(l, c, h)
+}
+
+func (c *Color) OKLab() [3]float64 {
+	if c.space == SpaceOKLCH {
+		l, a, b := oklchToOKLab(c.oklch[0], c.oklch[1], c.oklch[2])
+		return [3]float64{l, a, b}
+	}
+
+	l, a, b := linearRGBToOKLab(c.toLinearRGB())
+	return [3]float64{l, a, b}
+}
+
+func (c *Color) OKLCH() [3]float64 {
+	if c.space == SpaceOKLCH {
+		return c.oklch
+	}
+
+	l, a, b := linearRGBToOKLab(c.toLinearRGB())
+	l, ch, h := oklabToOKLCH(l, a, b)
+	return [3]float64{l, ch, h}
+}
+
+func (c *Color) oklchToSRGB() (float64, float64, float64) {
+	l, a, b := oklchToOKLab(c.oklch[0], c.oklch[1], c.oklch[2])
+	r, g, bl := oklabToLinearRGB(l, a, b)
+
+	return linearToSRGB(r), linearToSRGB(g), linearToSRGB(bl)
+}
+
+func linearRGBToOKLab(r, g, b float64) (float64, float64, float64) {
+	l := math.Cbrt(0.4122214708*r + 0.5363325363*g + 0.0514459929*b)
+	m := math.Cbrt(0.2119034982*r + 0.6806995451*g + 0.1073969566*b)
+	s := math.Cbrt(0.0883024619*r + 0.2817188376*g + 0.6299787005*b)
+
+	return 0.2104542553*l + 0.7936177850*m - 0.0040720468*s,
+		1.9779984951*l - 2.4285922050*m + 0.4505937099*s,
+		0.0259040371*l + 0.7827717662*m - 0.8086757660*s
+}
+
+func oklabToLinearRGB(l, a, b float64) (float64, float64, float64) {
+	lp := l + 0.3963377774*a + 0.2158037573*b
+	mp := l - 0.1055613458*a - 0.0638541728*b
+	sp := l - 0.0894841775*a - 1.2914855480*b
+
+	lp, mp, sp = lp*lp*lp, mp*mp*mp, sp*sp*sp
+
+	return 4.0767416621*lp - 3.3077115913*mp + 0.2309699292*sp,
+		-1.2684380046*lp + 2.6097574011*mp - 0.3413193965*sp,
+		-0.0041960863*lp - 0.7034186147*mp + 1.7076147010*sp
+}
+
+func oklabToOKLCH(l, a, b float64) (float64, float64, float64) {
+	return l, math.Sqrt(a*a + b*b), hueDegrees(a, b)
+}
+
+func oklchToOKLab(l, c, h float64) (float64, float64, float64) {
+	hRad := radians(h)
+	return l, c * math.Cos(hRad), c * math.Sin(hRad)
+}
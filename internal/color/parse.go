@@ -0,0 +1,302 @@
+package color
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// Parse reads a CSS Color 4 value: hex (3, 4, 6 or 8 digits), rgb(), hsl(),
+// hwb(), lab(), lch(), oklab(), oklch(), color() or a named color.
+func Parse(value string) (*Color, error) {
+	s := strings.ToLower(strings.TrimSpace(value))
+	if s == "" {
+		return nil, fmt.Errorf("empty color value")
+	}
+
+	if strings.HasPrefix(s, "#") {
+		return parseHex(value, s[1:])
+	}
+
+	open := strings.Index(s, "(")
+	if open < 0 {
+		if hex, exists := namedColors[s]; exists {
+			return NewColor(hex), nil
+		}
+		return nil, fmt.Errorf("invalid color %q: unknown color name", value)
+	}
+	if !strings.HasSuffix(s, ")") {
+		return nil, fmt.Errorf("invalid color %q: missing closing parenthesis", value)
+	}
+
+	name := strings.TrimSpace(s[:open])
+	inner := s[open+1 : len(s)-1]
+
+	if name == "color" {
+		c, _, err := parseColorFunction(inner)
+		if err != nil {
+			return nil, fmt.Errorf("invalid color %q: %w", value, err)
+		}
+		return c, nil
+	}
+
+	args, err := splitArgs(inner)
+	if err != nil {
+		return nil, fmt.Errorf("invalid color %q: %w", value, err)
+	}
+
+	var c *Color
+	switch name {
+	case "rgb", "rgba":
+		c, err = parseRGB(args)
+	case "hsl", "hsla":
+		c, err = parseHSL(args)
+	case "hwb":
+		c, err = parseHWB(args)
+	case "lab":
+		c, err = parseLab(args)
+	case "lch":
+		c, err = parseLCH(args)
+	case "oklab":
+		c, err = parseOKLab(args)
+	case "oklch":
+		c, err = parseOKLCH(args)
+	default:
+		return nil, fmt.Errorf("invalid color %q: unsupported function %q", value, name)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("invalid color %q: %w", value, err)
+	}
+
+	c.SetAlpha(args.alpha)
+	return c, nil
+}
+
+func parseHex(value, digits string) (*Color, error) {
+	for _, r := range digits {
+		if !strings.ContainsRune("0123456789abcdef", r) {
+			return nil, fmt.Errorf("invalid color %q: %q is not a hex digit", value, r)
+		}
+	}
+
+	switch len(digits) {
+	case 3, 4:
+		expanded := make([]byte, 0, 8)
+		for i := 0; i < len(digits); i++ {
+			expanded = append(expanded, digits[i], digits[i])
+		}
+		return NewColor(string(expanded)), nil
+	case 6, 8:
+		return NewColor(digits), nil
+	default:
+		return nil, fmt.Errorf("invalid color %q: hex colors must have 3, 4, 6 or 8 digits", value)
+	}
+}
+
+type colorArgs struct {
+	components []string
+	alpha      float64
+}
+
+// splitArgs accepts both the legacy comma-separated syntax and the modern
+// space-separated syntax with an optional "/ alpha".
+func splitArgs(s string) (colorArgs, error) {
+	args := colorArgs{alpha: 1}
+	var alpha string
+
+	if strings.Contains(s, ",") {
+		parts := strings.Split(s, ",")
+		for i := range parts {
+			parts[i] = strings.TrimSpace(parts[i])
+		}
+		if len(parts) == 4 {
+			alpha = parts[3]
+			parts = parts[:3]
+		}
+		args.components = parts
+	} else {
+		main, rest, hasAlpha := strings.Cut(s, "/")
+		args.components = strings.Fields(main)
+		if hasAlpha {
+			alpha = strings.TrimSpace(rest)
+			if alpha == "" {
+				return args, fmt.Errorf("missing alpha value after \"/\"")
+			}
+		}
+	}
+
+	if len(args.components) != 3 {
+		return args, fmt.Errorf("expected 3 components, found %d", len(args.components))
+	}
+	if alpha != "" {
+		v, err := parseNumberOrPercent(alpha, 1)
+		if err != nil {
+			return args, fmt.Errorf("alpha: %w", err)
+		}
+		args.alpha = clampFloat(v, 0, 1)
+	}
+
+	return args, nil
+}
+
+// parseNumberOrPercent parses a plain number, "none" (as 0) or a percentage
+// where 100% equals percentRef.
+func parseNumberOrPercent(field string, percentRef float64) (float64, error) {
+	if field == "none" {
+		return 0, nil
+	}
+
+	if strings.HasSuffix(field, "%") {
+		v, err := strconv.ParseFloat(strings.TrimSuffix(field, "%"), 64)
+		if err != nil {
+			return 0, fmt.Errorf("invalid percentage %q", field)
+		}
+		return v / 100 * percentRef, nil
+	}
+
+	v, err := strconv.ParseFloat(field, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid number %q", field)
+	}
+	return v, nil
+}
+
+func parseHue(field string) (float64, error) {
+	if field == "none" {
+		return 0, nil
+	}
+
+	units := []struct {
+		suffix string
+		scale  float64
+	}{
+		{"grad", 0.9},
+		{"turn", 360},
+		{"deg", 1},
+		{"rad", 180 / math.Pi},
+	}
+
+	scale := 1.0
+	for _, unit := range units {
+		if strings.HasSuffix(field, unit.suffix) {
+			field = strings.TrimSuffix(field, unit.suffix)
+			scale = unit.scale
+			break
+		}
+	}
+
+	v, err := strconv.ParseFloat(field, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid hue %q", field)
+	}
+
+	h := math.Mod(v*scale, 360)
+	if h < 0 {
+		h += 360
+	}
+	return h, nil
+}
+
+func parseComponents(args colorArgs, parsers ...func(string) (float64, error)) ([3]float64, error) {
+	var values [3]float64
+	for i, parse := range parsers {
+		v, err := parse(args.components[i])
+		if err != nil {
+			return values, err
+		}
+		values[i] = v
+	}
+	return values, nil
+}
+
+func percentOf(ref float64) func(string) (float64, error) {
+	return func(field string) (float64, error) {
+		return parseNumberOrPercent(field, ref)
+	}
+}
+
+func newFromSRGB(r, g, b float64) *Color {
+	return NewColor(srgbHex(r, g, b))
+}
+
+func parseRGB(args colorArgs) (*Color, error) {
+	v, err := parseComponents(args, percentOf(255), percentOf(255), percentOf(255))
+	if err != nil {
+		return nil, err
+	}
+	return newFromSRGB(v[0]/255, v[1]/255, v[2]/255), nil
+}
+
+func parseHSL(args colorArgs) (*Color, error) {
+	v, err := parseComponents(args, parseHue, percentOf(100), percentOf(100))
+	if err != nil {
+		return nil, err
+	}
+
+	r, g, b := hslToSRGB(v[0], clampFloat(v[1]/100, 0, 1), clampFloat(v[2]/100, 0, 1))
+	return newFromSRGB(r, g, b), nil
+}
+
+func parseHWB(args colorArgs) (*Color, error) {
+	v, err := parseComponents(args, parseHue, percentOf(100), percentOf(100))
+	if err != nil {
+		return nil, err
+	}
+
+	white, black := clampFloat(v[1]/100, 0, 1), clampFloat(v[2]/100, 0, 1)
+	if white+black >= 1 {
+		gray := white / (white + black)
+		return newFromSRGB(gray, gray, gray), nil
+	}
+
+	r, g, b := hslToSRGB(v[0], 1, 0.5)
+	scale := 1 - white - black
+	return newFromSRGB(r*scale+white, g*scale+white, b*scale+white), nil
+}
+
+func parseLab(args colorArgs) (*Color, error) {
+	v, err := parseComponents(args, percentOf(100), percentOf(125), percentOf(125))
+	if err != nil {
+		return nil, err
+	}
+
+	l, c, h := labToLCH(clampFloat(v[0], 0, 100), v[1], v[2])
+	return NewLCH(l, c, h), nil
+}
+
+func parseLCH(args colorArgs) (*Color, error) {
+	v, err := parseComponents(args, percentOf(100), percentOf(150), parseHue)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewLCH(clampFloat(v[0], 0, 100), math.Max(0, v[1]), v[2]), nil
+}
+
+func parseOKLab(args colorArgs) (*Color, error) {
+	v, err := parseComponents(args, percentOf(1), percentOf(0.4), percentOf(0.4))
+	if err != nil {
+		return nil, err
+	}
+
+	return NewOKLab(clampFloat(v[0], 0, 1), v[1], v[2]), nil
+}
+
+func parseOKLCH(args colorArgs) (*Color, error) {
+	v, err := parseComponents(args, percentOf(1), percentOf(0.4), parseHue)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewOKLCH(clampFloat(v[0], 0, 1), math.Max(0, v[1]), v[2]), nil
+}
+
+func hslToSRGB(h, s, l float64) (float64, float64, float64) {
+	f := func(n float64) float64 {
+		k := math.Mod(n+h/30, 12)
+		a := s * math.Min(l, 1-l)
+		return l - a*math.Max(-1, math.Min(math.Min(k-3, 9-k), 1))
+	}
+	return f(0), f(8), f(4)
+}
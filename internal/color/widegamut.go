@@ -0,0 +1,188 @@
+package color
+
+import (
+	"fmt"
+	"math"
+	"strings"
+)
+
+type RGBSpace string
+
+const (
+	RGBSpaceSRGB      RGBSpace = "srgb"
+	RGBSpaceDisplayP3 RGBSpace = "display-p3"
+	RGBSpaceRec2020   RGBSpace = "rec2020"
+)
+
+// Bradford chromatic adaptation from D65 to D50, as used by CSS Color 4.
+var d65ToD50 = [3][3]float64{
+	{1.0479297925449969, 0.022946870601609652, -0.05019226628920524},
+	{0.02962780877005599, 0.9904344267538799, -0.017073799063418826},
+	{-0.009243040646204504, 0.015055191490298152, 0.7518742814281371},
+}
+
+var (
+	displayP3ToXYZ = multiply3x3(d65ToD50, [3][3]float64{
+		{608311.0 / 1250200, 189793.0 / 714400, 198249.0 / 1000160},
+		{35783.0 / 156275, 247089.0 / 357200, 198249.0 / 2500400},
+		{0, 32229.0 / 714400, 5220557.0 / 5000800},
+	})
+	xyzToDisplayP3 = invert3x3(displayP3ToXYZ)
+
+	rec2020ToXYZ = multiply3x3(d65ToD50, [3][3]float64{
+		{63426534.0 / 99577255, 20160776.0 / 139408157, 47086771.0 / 278816314},
+		{26158966.0 / 99577255, 472592308.0 / 697040785, 8267143.0 / 139408157},
+		{0, 19567812.0 / 697040785, 295819943.0 / 278816314},
+	})
+	xyzToRec2020 = invert3x3(rec2020ToXYZ)
+)
+
+const (
+	rec2020Alpha = 1.09929682680944
+	rec2020Beta  = 0.018053968510807
+)
+
+func NewDisplayP3(r, g, b float64) *Color {
+	x, y, z := apply3x3(displayP3ToXYZ, srgbToLinear(r), srgbToLinear(g), srgbToLinear(b))
+	return newFromXYZ(x, y, z)
+}
+
+func NewRec2020(r, g, b float64) *Color {
+	x, y, z := apply3x3(rec2020ToXYZ, rec2020ToLinear(r), rec2020ToLinear(g), rec2020ToLinear(b))
+	return newFromXYZ(x, y, z)
+}
+
+func newFromXYZ(x, y, z float64) *Color {
+	l, a, b := linearRGBToOKLab(xyzToLinearRGB(x, y, z))
+	l, ch, h := oklabToOKLCH(l, a, b)
+	return NewOKLCH(l, ch, h)
+}
+
+func (c *Color) DisplayP3() [3]float64 {
+	x, y, z := linearRGBToXYZ(c.toLinearRGB())
+	r, g, b := apply3x3(xyzToDisplayP3, x, y, z)
+
+	return [3]float64{
+		clampFloat(linearToSRGB(r), 0, 1),
+		clampFloat(linearToSRGB(g), 0, 1),
+		clampFloat(linearToSRGB(b), 0, 1),
+	}
+}
+
+func (c *Color) Rec2020() [3]float64 {
+	x, y, z := linearRGBToXYZ(c.toLinearRGB())
+	r, g, b := apply3x3(xyzToRec2020, x, y, z)
+
+	return [3]float64{
+		clampFloat(linearToRec2020(r), 0, 1),
+		clampFloat(linearToRec2020(g), 0, 1),
+		clampFloat(linearToRec2020(b), 0, 1),
+	}
+}
+
+// ParseColorFunction parses the CSS color() function for the srgb,
+// display-p3 and rec2020 predefined spaces, e.g. "color(display-p3 1 0.5 0)".
+func ParseColorFunction(value string) (*Color, RGBSpace, error) {
+	s := strings.ToLower(strings.TrimSpace(value))
+	if !strings.HasPrefix(s, "color(") || !strings.HasSuffix(s, ")") {
+		return nil, "", fmt.Errorf("%q is not a color() function", value)
+	}
+
+	c, space, err := parseColorFunction(s[len("color(") : len(s)-1])
+	if err != nil {
+		return nil, "", fmt.Errorf("invalid color %q: %w", value, err)
+	}
+	return c, space, nil
+}
+
+func parseColorFunction(inner string) (*Color, RGBSpace, error) {
+	spaceName, rest, _ := strings.Cut(strings.TrimSpace(inner), " ")
+
+	args, err := splitArgs(rest)
+	if err != nil {
+		return nil, "", err
+	}
+	v, err := parseComponents(args, percentOf(1), percentOf(1), percentOf(1))
+	if err != nil {
+		return nil, "", err
+	}
+
+	var c *Color
+	space := RGBSpace(spaceName)
+	switch space {
+	case RGBSpaceSRGB:
+		c = newFromSRGB(v[0], v[1], v[2])
+	case RGBSpaceDisplayP3:
+		c = NewDisplayP3(v[0], v[1], v[2])
+	case RGBSpaceRec2020:
+		c = NewRec2020(v[0], v[1], v[2])
+	default:
+		return nil, "", fmt.Errorf("unsupported color space %q", spaceName)
+	}
+
+	c.SetAlpha(args.alpha)
+	return c, space, nil
+}
+
+func srgbHex(r, g, b float64) string {
+	return fmt.Sprintf("%02x%02x%02x",
+		int(math.Round(clampFloat(r, 0, 1)*255)),
+		int(math.Round(clampFloat(g, 0, 1)*255)),
+		int(math.Round(clampFloat(b, 0, 1)*255)))
+}
+
+func rec2020ToLinear(val float64) float64 {
+	if val < rec2020Beta*4.5 {
+		return val / 4.5
+	}
+	return math.Pow((val+rec2020Alpha-1)/rec2020Alpha, 1/0.45)
+}
+
+func linearToRec2020(val float64) float64 {
+	if val <= rec2020Beta {
+		return 4.5 * val
+	}
+	return rec2020Alpha*math.Pow(val, 0.45) - (rec2020Alpha - 1)
+}
+
+func apply3x3(m [3][3]float64, a, b, c float64) (float64, float64, float64) {
+	return m[0][0]*a + m[0][1]*b + m[0][2]*c,
+		m[1][0]*a + m[1][1]*b + m[1][2]*c,
+		m[2][0]*a + m[2][1]*b + m[2][2]*c
+}
+
+func multiply3x3(a, b [3][3]float64) [3][3]float64 {
+	var out [3][3]float64
+	for i := 0; i < 3; i++ {
+		for j := 0; j < 3; j++ {
+			for k := 0; k < 3; k++ {
+				out[i][j] += a[i][k] * b[k][j]
+			}
+		}
+	}
+	return out
+}
+
+func invert3x3(m [3][3]float64) [3][3]float64 {
+	det := m[0][0]*(m[1][1]*m[2][2]-m[1][2]*m[2][1]) -
+		m[0][1]*(m[1][0]*m[2][2]-m[1][2]*m[2][0]) +
+		m[0][2]*(m[1][0]*m[2][1]-m[1][1]*m[2][0])
+
+	return [3][3]float64{
+		{
+			(m[1][1]*m[2][2] - m[1][2]*m[2][1]) / det,
+			(m[0][2]*m[2][1] - m[0][1]*m[2][2]) / det,
+			(m[0][1]*m[1][2] - m[0][2]*m[1][1]) / det,
+		},
+		{
+			(m[1][2]*m[2][0] - m[1][0]*m[2][2]) / det,
+			(m[0][0]*m[2][2] - m[0][2]*m[2][0]) / det,
+			(m[0][2]*m[1][0] - m[0][0]*m[1][2]) / det,
+		},
+		{
+			(m[1][0]*m[2][1] - m[1][1]*m[2][0]) / det,
+			(m[0][1]*m[2][0] - m[0][0]*m[2][1]) / det,
+			(m[0][0]*m[1][1] - m[0][1]*m[1][0]) / det,
+		},
+	}
+}
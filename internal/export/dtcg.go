@@ -0,0 +1,134 @@
+package export
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+
+	"github.com/openpalettestandard/openpalette/internal/palette"
+	"github.com/openpalettestandard/openpalette/internal/types"
+)
+
+func init() {
+	Register(dtcg{})
+}
+
+// dtcgExtension names the metadata that ImportDTCG reads back.
+const dtcgExtension = palette.DTCGExtension
+
+// dtcg writes a variant as W3C Design Tokens Community Group JSON, with the
+// colors in accent, semantic, translucent and ansi groups.
+type dtcg struct{}
+
+func (dtcg) Name() string        { return "dtcg" }
+func (dtcg) Description() string { return "W3C Design Tokens (DTCG) JSON" }
+func (dtcg) Port() string        { return "tokens" }
+
+func (dtcg) FileName(variantID string) string {
+	return variantID + ".tokens.json"
+}
+
+func (dtcg) Export(w io.Writer, palette types.PaletteResult, variantID string, _ Options) error {
+	variant := palette.Variants[variantID]
+	ids := colorIDs(variant.PaletteColors, variant.ColorOrder)
+
+	var buf bytes.Buffer
+	buf.WriteString("{")
+	writeMember(&buf, "$description", variant.Name, true)
+	buf.WriteString(`,"$extensions":{`)
+	buf.WriteString(jsString(dtcgExtension) + ":{")
+	writeMember(&buf, "id", variantID, true)
+	writeMember(&buf, "name", variant.Name, false)
+	writeMember(&buf, "emoji", variant.Emoji, false)
+	writeMember(&buf, "dark", variant.Dark, false)
+	buf.WriteString("}}")
+
+	writeGroup := func(name string, tokens func()) {
+		buf.WriteString("," + jsString(name) + ":{")
+		writeMember(&buf, "$type", "color", true)
+		tokens()
+		buf.WriteString("}")
+	}
+	writeToken := func(id, name, hex string) {
+		buf.WriteString("," + jsString(id) + ":{")
+		writeMember(&buf, "$value", hex, true)
+		writeMember(&buf, "$description", name, false)
+		buf.WriteString("}")
+	}
+
+	for _, accent := range []bool{true, false} {
+		group := "semantic"
+		if accent {
+			group = "accent"
+		}
+		writeGroup(group, func() {
+			for _, id := range ids {
+				if c := variant.PaletteColors[id]; c.Accent == accent {
+					writeToken(id, c.Name, c.Hex)
+				}
+			}
+		})
+	}
+
+	if len(variant.TranslucentColors) > 0 {
+		writeGroup("translucent", func() {
+			for _, id := range colorIDs(variant.TranslucentColors, nil) {
+				c := variant.TranslucentColors[id]
+				writeToken(id, c.Name, c.Hex)
+			}
+		})
+	}
+
+	writeGroup("ansi", func() {
+		for _, bright := range []bool{false, true} {
+			group := "normal"
+			if bright {
+				group = "bright"
+			}
+			buf.WriteString("," + jsString(group) + ":{")
+			first := true
+			for _, ansiName := range ansiNames {
+				ansiColor, exists := variant.AnsiPaletteColors[ansiName]
+				if !exists {
+					continue
+				}
+				v := ansiColor.Normal
+				if bright {
+					v = ansiColor.Bright
+				}
+				if !first {
+					buf.WriteString(",")
+				}
+				first = false
+				buf.WriteString(jsString(ansiName) + ":{")
+				writeMember(&buf, "$value", v.Hex, true)
+				writeMember(&buf, "$description", v.Name, false)
+				buf.WriteString("}")
+			}
+			buf.WriteString("}")
+		}
+	})
+	buf.WriteString("}")
+
+	var indented bytes.Buffer
+	if err := json.Indent(&indented, buf.Bytes(), "", "  "); err != nil {
+		return err
+	}
+	indented.WriteString("\n")
+
+	_, err := w.Write(indented.Bytes())
+	return err
+}
+
+func writeMember(buf *bytes.Buffer, key string, value any, first bool) {
+	if !first {
+		buf.WriteString(",")
+	}
+	buf.WriteString(jsString(key) + ":")
+	if s, ok := value.(string); ok {
+		buf.WriteString(jsString(s))
+	} else {
+		data, _ := json.Marshal(value)
+		buf.Write(data)
+	}
+}
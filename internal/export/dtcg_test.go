@@ -0,0 +1,41 @@
+package export
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/openpalettestandard/openpalette/internal/palette"
+)
+
+func TestDTCGRoundTrip(t *testing.T) {
+	result := testPalette()
+
+	exporter, err := Lookup("dtcg")
+	if err != nil {
+		t.Fatalf("looking up exporter: %v", err)
+	}
+
+	var buf bytes.Buffer
+	if err := exporter.Export(&buf, result, "night", Options{}); err != nil {
+		t.Fatalf("exporting: %v", err)
+	}
+
+	id, variant, err := palette.ParseDTCG(buf.Bytes(), "fallback")
+	if err != nil {
+		t.Fatalf("importing: %v", err)
+	}
+
+	original := result.Variants["night"]
+	if id != "night" || variant.Name != original.Name || variant.Dark != original.Dark || variant.Emoji != original.Emoji {
+		t.Errorf("expected the variant metadata to survive, got %q %+v", id, variant)
+	}
+	if len(variant.Colors) != len(original.PaletteColors) {
+		t.Errorf("expected %d colors, got %d", len(original.PaletteColors), len(variant.Colors))
+	}
+	for colorID, c := range original.PaletteColors {
+		imported := variant.Colors[colorID]
+		if imported.Hex != c.Hex || imported.Name != c.Name || imported.Accent != c.Accent {
+			t.Errorf("%s: expected %s %q accent %t, got %+v", colorID, c.Hex, c.Name, c.Accent, imported)
+		}
+	}
+}
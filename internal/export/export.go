@@ -0,0 +1,170 @@
+package export
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/openpalettestandard/openpalette/internal/types"
+)
+
+// Exporter writes one variant of a palette in a platform format.
+type Exporter interface {
+	// Name is the value of export --format.
+	Name() string
+	Description() string
+
+	// Port is the directory under ports/ that files are written to, such as
+	// "terminal" or "web".
+	Port() string
+	FileName(variantID string) string
+
+	Export(w io.Writer, palette types.PaletteResult, variantID string, opts Options) error
+}
+
+// Combined is implemented by exporters that write every selected variant to
+// a single file, for formats that switch between variants themselves.
+type Combined interface {
+	CombinedFileName() string
+	ExportCombined(w io.Writer, palette types.PaletteResult, variantIDs []string, opts Options) error
+}
+
+// Style selects how color values are written by formats that support more
+// than one notation.
+type Style string
+
+const (
+	StyleHex   Style = "hex"
+	StyleRGB   Style = "rgb"
+	StyleOKLCH Style = "oklch"
+)
+
+type Options struct {
+	Style Style
+}
+
+func ParseStyle(name string) (Style, error) {
+	switch style := Style(name); style {
+	case StyleHex, StyleRGB, StyleOKLCH:
+		return style, nil
+	}
+	return "", fmt.Errorf("unknown value style %q (expected hex, rgb or oklch)", name)
+}
+
+var exporters = make(map[string]Exporter)
+
+// ansiNames lists the eight normal ANSI colors in code order.
+var ansiNames = []string{"black", "red", "green", "yellow", "blue", "magenta", "cyan", "white"}
+
+// Register makes an exporter available to Lookup. It panics if the name is
+// already taken.
+func Register(exporter Exporter) {
+	if _, exists := exporters[exporter.Name()]; exists {
+		panic(fmt.Sprintf("export: exporter %q registered twice", exporter.Name()))
+	}
+	exporters[exporter.Name()] = exporter
+}
+
+func Lookup(name string) (Exporter, error) {
+	exporter, exists := exporters[name]
+	if !exists {
+		return nil, fmt.Errorf("unknown export format %q (expected %s)", name, strings.Join(Names(), ", "))
+	}
+	return exporter, nil
+}
+
+func Names() []string {
+	names := make([]string, 0, len(exporters))
+	for name := range exporters {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// SelectVariants returns the requested variant IDs in palette order, or every
+// variant when ids is empty.
+func SelectVariants(palette types.PaletteResult, ids []string) ([]string, error) {
+	for _, id := range ids {
+		if _, exists := palette.Variants[id]; !exists {
+			return nil, fmt.Errorf("unknown variant %q", id)
+		}
+	}
+
+	selected := make([]string, 0, len(palette.Variants))
+	for id := range palette.Variants {
+		if len(ids) == 0 || contains(ids, id) {
+			selected = append(selected, id)
+		}
+	}
+	sort.Slice(selected, func(i, j int) bool {
+		a, b := palette.Variants[selected[i]], palette.Variants[selected[j]]
+		if a.Order != b.Order {
+			return a.Order < b.Order
+		}
+		return selected[i] < selected[j]
+	})
+	return selected, nil
+}
+
+// WriteFiles exports each variant to its own file under dir, laid out as in
+// section 8.1 of the specification: dir/ports/<port>/<file>. Combined
+// exporters write a single file instead. It returns the paths it wrote.
+func WriteFiles(exporter Exporter, palette types.PaletteResult, variantIDs []string, dir string, opts Options) ([]string, error) {
+	portDir := filepath.Join(dir, "ports", exporter.Port())
+	if err := os.MkdirAll(portDir, 0755); err != nil {
+		return nil, fmt.Errorf("creating %s: %w", portDir, err)
+	}
+
+	if combined, ok := exporter.(Combined); ok {
+		filename := filepath.Join(portDir, combined.CombinedFileName())
+		err := writeFile(filename, func(w io.Writer) error {
+			return combined.ExportCombined(w, palette, variantIDs, opts)
+		})
+		if err != nil {
+			return nil, err
+		}
+		return []string{filename}, nil
+	}
+
+	var written []string
+	for _, id := range variantIDs {
+		filename := filepath.Join(portDir, exporter.FileName(id))
+		err := writeFile(filename, func(w io.Writer) error {
+			if err := exporter.Export(w, palette, id, opts); err != nil {
+				return fmt.Errorf("exporting %s: %w", id, err)
+			}
+			return nil
+		})
+		if err != nil {
+			return written, err
+		}
+		written = append(written, filename)
+	}
+	return written, nil
+}
+
+func writeFile(filename string, export func(io.Writer) error) error {
+	file, err := os.Create(filename)
+	if err != nil {
+		return fmt.Errorf("creating %s: %w", filename, err)
+	}
+	defer file.Close()
+
+	if err := export(file); err != nil {
+		return err
+	}
+	return file.Close()
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,92 @@
+package export
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/openpalettestandard/openpalette/internal/palette"
+	"github.com/openpalettestandard/openpalette/internal/types"
+)
+
+// testPalette has latte twice, with the copy ordered first.
+func testPalette() types.PaletteResult {
+	result := palette.Generate(palette.Options{})
+
+	night := result.Variants["latte"]
+	night.Name, night.Dark, night.Order = "Night", true, -1
+	result.Variants["night"] = night
+	return result
+}
+
+func TestSelectVariants(t *testing.T) {
+	result := testPalette()
+
+	all, err := SelectVariants(result, nil)
+	if err != nil {
+		t.Fatalf("selecting all variants: %v", err)
+	}
+	if want := []string{"night", "latte"}; !reflect.DeepEqual(all, want) {
+		t.Errorf("expected %v, got %v", want, all)
+	}
+
+	if _, err := SelectVariants(result, []string{"mocha"}); err == nil {
+		t.Error("expected an error for an unknown variant")
+	}
+}
+
+func TestWriteFiles(t *testing.T) {
+	exporter, err := Lookup("xresources")
+	if err != nil {
+		t.Fatalf("looking up exporter: %v", err)
+	}
+	if _, err := Lookup("nope"); err == nil || !strings.Contains(err.Error(), "xresources") {
+		t.Errorf("expected an unknown format error listing the formats, got %v", err)
+	}
+
+	dir := t.TempDir()
+	written, err := WriteFiles(exporter, testPalette(), []string{"latte", "night"}, dir, Options{})
+	if err != nil {
+		t.Fatalf("writing files: %v", err)
+	}
+
+	want := []string{
+		filepath.Join(dir, "ports", "terminal", "latte.Xresources"),
+		filepath.Join(dir, "ports", "terminal", "night.Xresources"),
+	}
+	if !reflect.DeepEqual(written, want) {
+		t.Fatalf("expected %v, got %v", want, written)
+	}
+
+	data, err := os.ReadFile(written[0])
+	if err != nil {
+		t.Fatalf("reading export: %v", err)
+	}
+	for _, line := range []string{"*.background: #eff1f5", "*.color1: #d20f39", "*.color15: "} {
+		if !strings.Contains(string(data), line) {
+			t.Errorf("expected export to contain %q, got:\n%s", line, data)
+		}
+	}
+}
+
+func TestXresourcesMissingColors(t *testing.T) {
+	result := testPalette()
+	night := result.Variants["night"]
+	night.PaletteColors = map[string]types.PaletteColor{"red": night.PaletteColors["red"]}
+	result.Variants["night"] = night
+
+	var buf strings.Builder
+	if err := (xresources{}).Export(&buf, result, "night", Options{}); err != nil {
+		t.Fatalf("exporting: %v", err)
+	}
+	for _, line := range strings.Split(strings.TrimSpace(buf.String()), "\n") {
+		if strings.HasSuffix(line, ": ") || strings.HasSuffix(line, ":") {
+			t.Errorf("expected no resource without a value, got %q", line)
+		}
+	}
+	if strings.Contains(buf.String(), "*.foreground") {
+		t.Errorf("expected foreground to be left out without text, got:\n%s", buf.String())
+	}
+}
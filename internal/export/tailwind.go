@@ -0,0 +1,253 @@
+package export
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/openpalettestandard/openpalette/internal/types"
+)
+
+func init() {
+	Register(tailwind{})
+	Register(tailwindV4{})
+}
+
+// themeBlock is one rule set of a theme that switches between variants.
+type themeBlock struct {
+	Media     string
+	Selector  string
+	VariantID string
+}
+
+// themeBlocks lists the rule sets that define the variables of each variant.
+// :root takes the first variant, pages without data-theme follow
+// prefers-color-scheme to the first variant of the other polarity, and every
+// variant can be picked with its own data-theme selector.
+func themeBlocks(palette types.PaletteResult, variantIDs []string) []themeBlock {
+	if len(variantIDs) == 0 {
+		return nil
+	}
+
+	first := palette.Variants[variantIDs[0]]
+	blocks := []themeBlock{{Selector: ":root", VariantID: variantIDs[0]}}
+	for _, id := range variantIDs {
+		if variant := palette.Variants[id]; variant.Dark != first.Dark {
+			scheme := "light"
+			if variant.Dark {
+				scheme = "dark"
+			}
+			blocks = append(blocks, themeBlock{
+				Media:     "(prefers-color-scheme: " + scheme + ")",
+				Selector:  ":root:not([data-theme])",
+				VariantID: id,
+			})
+			break
+		}
+	}
+	for _, id := range variantIDs {
+		blocks = append(blocks, themeBlock{Selector: themeSelector(id), VariantID: id})
+	}
+	return blocks
+}
+
+func themeSelector(variantID string) string {
+	return fmt.Sprintf("[data-theme=%q]", variantID)
+}
+
+// darkSelector matches elements inside any of the dark variants, for the
+// dark: variant of Tailwind. It is empty when no variant is dark.
+func darkSelector(palette types.PaletteResult, variantIDs []string) string {
+	var selectors []string
+	for _, id := range variantIDs {
+		if palette.Variants[id].Dark {
+			selectors = append(selectors, themeSelector(id))
+		}
+	}
+	return strings.Join(selectors, ", ")
+}
+
+// darkVariant is the selector of the dark: variant of Tailwind, matching the
+// dark variants and their descendants. It is empty when no variant is dark.
+func darkVariant(palette types.PaletteResult, variantIDs []string) string {
+	selector := darkSelector(palette, variantIDs)
+	if selector == "" {
+		return ""
+	}
+	return fmt.Sprintf("&:where(%s, %s)", selector, descendants(selector))
+}
+
+// themeColors lists the variable names used by any of the variants, in the
+// order they first appear, and reports which of them carry their own alpha.
+func themeColors(palette types.PaletteResult, variantIDs []string) ([]string, map[string]bool) {
+	var names []string
+	seen := make(map[string]bool)
+	translucent := make(map[string]bool)
+	for _, id := range variantIDs {
+		variant := palette.Variants[id]
+		for _, variable := range webVariables(variant) {
+			if !seen[variable.Name] {
+				seen[variable.Name] = true
+				names = append(names, variable.Name)
+			}
+		}
+		for translucentID := range variant.TranslucentColors {
+			translucent[translucentID] = true
+		}
+	}
+	return names, translucent
+}
+
+// tailwind writes a Tailwind CSS v3 preset. Colors refer to variables holding
+// RGB channels, which a base plugin defines for each variant, so that opacity
+// modifiers such as bg-base/50 work.
+type tailwind struct{}
+
+func (tailwind) Name() string        { return "tailwind" }
+func (tailwind) Description() string { return "Tailwind CSS v3 preset" }
+func (tailwind) Port() string        { return "web" }
+
+func (tailwind) FileName(variantID string) string {
+	return variantID + ".tailwind.preset.js"
+}
+
+func (tailwind) CombinedFileName() string {
+	return "tailwind.preset.js"
+}
+
+func (t tailwind) Export(w io.Writer, palette types.PaletteResult, variantID string, opts Options) error {
+	return t.ExportCombined(w, palette, []string{variantID}, opts)
+}
+
+func (tailwind) ExportCombined(w io.Writer, palette types.PaletteResult, variantIDs []string, _ Options) error {
+	names, translucent := themeColors(palette, variantIDs)
+
+	var buf bytes.Buffer
+	buf.WriteString("// Tailwind CSS preset generated by openpalette.\n")
+	buf.WriteString("module.exports = {\n")
+	// The selector strategy only adds descendants of the last selector in a
+	// list, so the variant strategy is given the full selector instead.
+	if variant := darkVariant(palette, variantIDs); variant != "" {
+		fmt.Fprintf(&buf, "  darkMode: [\"variant\", %s],\n", jsString(variant))
+	}
+
+	buf.WriteString("  theme: {\n    extend: {\n      colors: {\n")
+	for _, name := range names {
+		value := "rgb(var(--" + webPrefix + name + ") / <alpha-value>)"
+		if translucent[name] {
+			value = "rgb(var(--" + webPrefix + name + "))"
+		}
+		fmt.Fprintf(&buf, "        %s: %s,\n", jsString(name), jsString(value))
+	}
+	buf.WriteString("      },\n    },\n  },\n")
+
+	buf.WriteString("  plugins: [\n    function ({ addBase }) {\n      addBase({\n")
+	writeRules := func(selector, indent string, variant types.PaletteVariant) {
+		fmt.Fprintf(&buf, "%s%s: {\n", indent, jsString(selector))
+		for _, variable := range webVariables(variant) {
+			fmt.Fprintf(&buf, "%s  %s: %s,\n", indent, jsString("--"+webPrefix+variable.Name), jsString(formatValue(variable.Hex, StyleRGB)))
+		}
+		fmt.Fprintf(&buf, "%s},\n", indent)
+	}
+	for _, block := range themeBlocks(palette, variantIDs) {
+		variant := palette.Variants[block.VariantID]
+		if block.Media == "" {
+			writeRules(block.Selector, "        ", variant)
+			continue
+		}
+		fmt.Fprintf(&buf, "        %s: {\n", jsString("@media "+block.Media))
+		writeRules(block.Selector, "          ", variant)
+		buf.WriteString("        },\n")
+	}
+	buf.WriteString("      });\n    },\n  ],\n};\n")
+
+	_, err := w.Write(buf.Bytes())
+	return err
+}
+
+// jsString quotes s as a JSON string, which is also a JavaScript string
+// literal, without escaping HTML characters.
+func jsString(s string) string {
+	var buf bytes.Buffer
+	encoder := json.NewEncoder(&buf)
+	encoder.SetEscapeHTML(false)
+	encoder.Encode(s)
+	return strings.TrimSuffix(buf.String(), "\n")
+}
+
+// tailwindV4 writes Tailwind CSS v4 theme variables. @theme inline maps the
+// color utilities to the palette variables, which are defined for each
+// variant in plain CSS.
+type tailwindV4 struct{}
+
+func (tailwindV4) Name() string        { return "tailwind-v4" }
+func (tailwindV4) Description() string { return "Tailwind CSS v4 @theme" }
+func (tailwindV4) Port() string        { return "web" }
+
+func (tailwindV4) FileName(variantID string) string {
+	return variantID + ".tailwind.css"
+}
+
+func (tailwindV4) CombinedFileName() string {
+	return "tailwind.css"
+}
+
+func (t tailwindV4) Export(w io.Writer, palette types.PaletteResult, variantID string, opts Options) error {
+	return t.ExportCombined(w, palette, []string{variantID}, opts)
+}
+
+func (tailwindV4) ExportCombined(w io.Writer, palette types.PaletteResult, variantIDs []string, opts Options) error {
+	names, _ := themeColors(palette, variantIDs)
+
+	// A bare channel list is not a color in CSS, so the rgb style is wrapped.
+	value := func(hex string) string {
+		if opts.Style == StyleRGB {
+			return "rgb(" + formatValue(hex, StyleRGB) + ")"
+		}
+		return formatValue(hex, opts.Style)
+	}
+
+	var buf bytes.Buffer
+	buf.WriteString("/* Tailwind CSS theme generated by openpalette. */\n")
+	if variant := darkVariant(palette, variantIDs); variant != "" {
+		fmt.Fprintf(&buf, "@custom-variant dark (%s);\n", variant)
+	}
+
+	buf.WriteString("\n@theme inline {\n")
+	for _, name := range names {
+		fmt.Fprintf(&buf, "  --color-%s: var(--%s%s);\n", name, webPrefix, name)
+	}
+	buf.WriteString("}\n")
+
+	for _, block := range themeBlocks(palette, variantIDs) {
+		indent := ""
+		buf.WriteString("\n")
+		if block.Media != "" {
+			fmt.Fprintf(&buf, "@media %s {\n", block.Media)
+			indent = "  "
+		}
+		fmt.Fprintf(&buf, "%s%s {\n", indent, block.Selector)
+		for _, variable := range webVariables(palette.Variants[block.VariantID]) {
+			fmt.Fprintf(&buf, "%s  --%s%s: %s;\n", indent, webPrefix, variable.Name, value(variable.Hex))
+		}
+		fmt.Fprintf(&buf, "%s}\n", indent)
+		if block.Media != "" {
+			buf.WriteString("}\n")
+		}
+	}
+
+	_, err := w.Write(buf.Bytes())
+	return err
+}
+
+// descendants turns a selector list into one that matches the descendants
+// of each selector.
+func descendants(selectorList string) string {
+	selectors := strings.Split(selectorList, ", ")
+	for i, selector := range selectors {
+		selectors[i] = selector + " *"
+	}
+	return strings.Join(selectors, ", ")
+}
@@ -0,0 +1,81 @@
+package export
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestTailwindExporters(t *testing.T) {
+	result := testPalette()
+
+	export := func(name string) string {
+		t.Helper()
+
+		exporter, err := Lookup(name)
+		if err != nil {
+			t.Fatalf("looking up %s: %v", name, err)
+		}
+		combined, ok := exporter.(Combined)
+		if !ok {
+			t.Fatalf("expected %s to export all variants to one file", name)
+		}
+
+		var first string
+		for i := 0; i < 5; i++ {
+			var buf bytes.Buffer
+			if err := combined.ExportCombined(&buf, result, []string{"night", "latte"}, Options{}); err != nil {
+				t.Fatalf("exporting %s: %v", name, err)
+			}
+			if i == 0 {
+				first = buf.String()
+			} else if buf.String() != first {
+				t.Fatalf("%s: run %d produced different output", name, i)
+			}
+		}
+		return first
+	}
+
+	preset := export("tailwind")
+	for _, want := range []string{
+		`darkMode: ["variant", "&:where([data-theme=\"night\"], [data-theme=\"night\"] *)"],`,
+		`"base": "rgb(var(--op-base) / <alpha-value>)",`,
+		`"ansi-bright-red": "rgb(var(--op-ansi-bright-red) / <alpha-value>)",`,
+		`"@media (prefers-color-scheme: light)": {`,
+		`"--op-base": "239 241 245",`,
+	} {
+		if !strings.Contains(preset, want) {
+			t.Errorf("expected preset to contain %q, got:\n%s", want, preset)
+		}
+	}
+	if root, night := strings.Index(preset, `":root": {`), strings.Index(preset, `"[data-theme=\"night\"]": {`); root < 0 || night < root {
+		t.Errorf("expected :root to come before the variant selectors")
+	}
+
+	theme := export("tailwind-v4")
+	for _, want := range []string{
+		`@custom-variant dark (&:where([data-theme="night"], [data-theme="night"] *));`,
+		"  --color-subtext1: var(--op-subtext1);",
+		"@media (prefers-color-scheme: light) {\n  :root:not([data-theme]) {",
+		"[data-theme=\"latte\"] {\n  --op-rosewater: #dc8a78;",
+	} {
+		if !strings.Contains(theme, want) {
+			t.Errorf("expected theme to contain %q, got:\n%s", want, theme)
+		}
+	}
+}
+
+func TestDarkVariantMatchesDescendants(t *testing.T) {
+	result := testPalette()
+	dusk := result.Variants["night"]
+	dusk.Name, dusk.Order = "Dusk", -2
+	result.Variants["dusk"] = dusk
+
+	want := `&:where([data-theme="dusk"], [data-theme="night"], [data-theme="dusk"] *, [data-theme="night"] *)`
+	if got := darkVariant(result, []string{"dusk", "night", "latte"}); got != want {
+		t.Errorf("expected %s, got %s", want, got)
+	}
+	if got := darkVariant(result, []string{"latte"}); got != "" {
+		t.Errorf("expected no dark variant without dark variants, got %s", got)
+	}
+}
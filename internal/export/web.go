@@ -0,0 +1,226 @@
+package export
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"math"
+	"sort"
+	"strconv"
+
+	"github.com/openpalettestandard/openpalette/internal/color"
+	"github.com/openpalettestandard/openpalette/internal/types"
+)
+
+func init() {
+	Register(css{})
+	Register(scss{})
+	Register(less{})
+}
+
+// webPrefix starts every variable name written by the web formats.
+const webPrefix = "op-"
+
+type webVariable struct {
+	Name string
+	Hex  string
+}
+
+// webVariables lists the colors of a variant under the names shared by the
+// web formats: palette colors and translucent colors by ID, followed by
+// ansi-<name> and ansi-bright-<name> for the ANSI colors the variant has.
+func webVariables(variant types.PaletteVariant) []webVariable {
+	var variables []webVariable
+	for _, id := range colorIDs(variant.PaletteColors, variant.ColorOrder) {
+		variables = append(variables, webVariable{id, variant.PaletteColors[id].Hex})
+	}
+	for _, id := range colorIDs(variant.TranslucentColors, nil) {
+		variables = append(variables, webVariable{id, variant.TranslucentColors[id].Hex})
+	}
+	for _, ansiName := range ansiNames {
+		if ansiColor, exists := variant.AnsiPaletteColors[ansiName]; exists {
+			variables = append(variables, webVariable{"ansi-" + ansiName, ansiColor.Normal.Hex})
+		}
+	}
+	for _, ansiName := range ansiNames {
+		if ansiColor, exists := variant.AnsiPaletteColors[ansiName]; exists {
+			variables = append(variables, webVariable{"ansi-bright-" + ansiName, ansiColor.Bright.Hex})
+		}
+	}
+	return variables
+}
+
+// colorIDs returns the IDs of colors in output order: those listed in order
+// first, as palette.json writes them, then the rest with extension colors
+// last.
+func colorIDs(colors map[string]types.PaletteColor, order []string) []string {
+	position := make(map[string]int, len(order))
+	for i, id := range order {
+		position[id] = i
+	}
+
+	ids := make([]string, 0, len(colors))
+	for id := range colors {
+		ids = append(ids, id)
+	}
+	sort.Slice(ids, func(i, j int) bool {
+		pa, aListed := position[ids[i]]
+		pb, bListed := position[ids[j]]
+		if aListed != bListed {
+			return aListed
+		}
+		if aListed {
+			return pa < pb
+		}
+
+		a, b := colors[ids[i]], colors[ids[j]]
+		if a.Extension != b.Extension {
+			return !a.Extension
+		}
+		if a.Order != b.Order {
+			return a.Order < b.Order
+		}
+		return ids[i] < ids[j]
+	})
+	return ids
+}
+
+// formatValue writes hex in the given style. The rgb style is a bare channel
+// list, as in "210 15 57", to be used inside rgb().
+func formatValue(hex string, style Style) string {
+	c := color.NewColor(hex)
+	alpha := ""
+	if c.Alpha() < 1 {
+		alpha = " / " + formatNumber(c.Alpha(), 3)
+	}
+
+	switch style {
+	case StyleRGB:
+		coords := c.ToSRGBGamut()
+		return fmt.Sprintf("%d %d %d%s",
+			int(math.Round(coords[0]*255)), int(math.Round(coords[1]*255)), int(math.Round(coords[2]*255)), alpha)
+	case StyleOKLCH:
+		coords := c.OKLCH()
+		chroma := formatNumber(coords[1], 4)
+		hue := formatNumber(coords[2], 2)
+		if chroma == "0" {
+			hue = "0"
+		}
+		return fmt.Sprintf("oklch(%s %s %s%s)", formatNumber(coords[0], 4), chroma, hue, alpha)
+	}
+	return hex
+}
+
+func formatNumber(value float64, decimals int) string {
+	scale := math.Pow(10, float64(decimals))
+	return strconv.FormatFloat(math.Round(value*scale)/scale, 'f', -1, 64)
+}
+
+// css writes custom properties scoped to [data-theme="<variant>"]. The first
+// variant of each polarity writes them again on :root for visitors whose
+// prefers-color-scheme matches it and who have not picked a theme, so that
+// loading several files of the same polarity does not depend on their order.
+type css struct{}
+
+func (css) Name() string        { return "css" }
+func (css) Description() string { return "CSS custom properties" }
+func (css) Port() string        { return "web" }
+
+func (css) FileName(variantID string) string {
+	return variantID + ".css"
+}
+
+func (css) Export(w io.Writer, palette types.PaletteResult, variantID string, opts Options) error {
+	variant := palette.Variants[variantID]
+	variables := webVariables(variant)
+
+	writeBlock := func(buf *bytes.Buffer, selector, indent string) {
+		fmt.Fprintf(buf, "%s%s {\n", indent, selector)
+		for _, variable := range variables {
+			fmt.Fprintf(buf, "%s  --%s%s: %s;\n", indent, webPrefix, variable.Name, formatValue(variable.Hex, opts.Style))
+		}
+		fmt.Fprintf(buf, "%s}\n", indent)
+	}
+
+	scheme := "light"
+	if variant.Dark {
+		scheme = "dark"
+	}
+
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "/* %s */\n", variant.Name)
+	writeBlock(&buf, themeSelector(variantID), "")
+	if schemeDefault(palette, variantID) {
+		fmt.Fprintf(&buf, "\n@media (prefers-color-scheme: %s) {\n", scheme)
+		writeBlock(&buf, ":root:not([data-theme])", "  ")
+		buf.WriteString("}\n")
+	}
+
+	_, err := w.Write(buf.Bytes())
+	return err
+}
+
+// schemeDefault reports whether variantID is the first variant of its
+// polarity in the palette.
+func schemeDefault(palette types.PaletteResult, variantID string) bool {
+	ids, _ := SelectVariants(palette, nil)
+	dark := palette.Variants[variantID].Dark
+	for _, id := range ids {
+		if palette.Variants[id].Dark == dark {
+			return id == variantID
+		}
+	}
+	return false
+}
+
+// scss writes a map named after the variant, so that several variants can
+// be imported side by side.
+type scss struct{}
+
+func (scss) Name() string        { return "scss" }
+func (scss) Description() string { return "SCSS map" }
+func (scss) Port() string        { return "web" }
+
+func (scss) FileName(variantID string) string {
+	return variantID + ".scss"
+}
+
+func (scss) Export(w io.Writer, palette types.PaletteResult, variantID string, opts Options) error {
+	variant := palette.Variants[variantID]
+
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "// %s\n", variant.Name)
+	fmt.Fprintf(&buf, "$%s%s: (\n", webPrefix, variantID)
+	for _, variable := range webVariables(variant) {
+		fmt.Fprintf(&buf, "  %q: %s,\n", variable.Name, formatValue(variable.Hex, opts.Style))
+	}
+	buf.WriteString(");\n")
+
+	_, err := w.Write(buf.Bytes())
+	return err
+}
+
+// less writes variables prefixed with the variant, as in @op-<variant>-red, so
+// that several variants can be imported side by side.
+type less struct{}
+
+func (less) Name() string        { return "less" }
+func (less) Description() string { return "Less variables" }
+func (less) Port() string        { return "web" }
+
+func (less) FileName(variantID string) string {
+	return variantID + ".less"
+}
+
+func (less) Export(w io.Writer, palette types.PaletteResult, variantID string, opts Options) error {
+	variant := palette.Variants[variantID]
+
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "// %s\n", variant.Name)
+	for _, variable := range webVariables(variant) {
+		fmt.Fprintf(&buf, "@%s%s-%s: %s;\n", webPrefix, variantID, variable.Name, formatValue(variable.Hex, opts.Style))
+	}
+
+	_, err := w.Write(buf.Bytes())
+	return err
+}
@@ -0,0 +1,91 @@
+package export
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestFormatValue(t *testing.T) {
+	tests := []struct {
+		hex   string
+		style Style
+		want  string
+	}{
+		{"#d20f39", StyleHex, "#d20f39"},
+		{"#d20f39", StyleRGB, "210 15 57"},
+		{"#d20f3980", StyleRGB, "210 15 57 / 0.502"},
+		{"#d20f39", StyleOKLCH, "oklch(0.5505 0.2155 19.81)"},
+		{"#ffffff", StyleOKLCH, "oklch(1 0 0)"},
+	}
+
+	for _, test := range tests {
+		if got := formatValue(test.hex, test.style); got != test.want {
+			t.Errorf("%s as %s: expected %q, got %q", test.hex, test.style, test.want, got)
+		}
+	}
+}
+
+func TestWebExporters(t *testing.T) {
+	result := testPalette()
+
+	export := func(name string, style Style) string {
+		t.Helper()
+
+		exporter, err := Lookup(name)
+		if err != nil {
+			t.Fatalf("looking up %s: %v", name, err)
+		}
+
+		var buf bytes.Buffer
+		if err := exporter.Export(&buf, result, "night", Options{Style: style}); err != nil {
+			t.Fatalf("exporting %s: %v", name, err)
+		}
+		return buf.String()
+	}
+
+	css := export("css", StyleRGB)
+	for _, want := range []string{
+		`[data-theme="night"] {`,
+		"@media (prefers-color-scheme: dark) {",
+		"  :root:not([data-theme]) {",
+		"  --op-red: 210 15 57;",
+		"  --op-ansi-bright-white: ",
+	} {
+		if !strings.Contains(css, want) {
+			t.Errorf("expected CSS to contain %q, got:\n%s", want, css)
+		}
+	}
+	if count := strings.Count(css, "--op-"); count != 2*(26+16) {
+		t.Errorf("expected 42 variables in each of the two blocks, got %d in total", count)
+	}
+
+	if scss := export("scss", StyleHex); !strings.Contains(scss, "$op-night: (\n") || !strings.Contains(scss, `  "red": #d20f39,`) {
+		t.Errorf("unexpected SCSS:\n%s", scss)
+	}
+	if less := export("less", StyleOKLCH); !strings.Contains(less, "@op-night-red: oklch(0.5505 0.2155 19.81);") {
+		t.Errorf("unexpected Less:\n%s", less)
+	}
+}
+
+func TestCSSSchemeDefault(t *testing.T) {
+	result := testPalette()
+	dusk := result.Variants["night"]
+	dusk.Name, dusk.Order = "Dusk", 5
+	result.Variants["dusk"] = dusk
+
+	exporter, err := Lookup("css")
+	if err != nil {
+		t.Fatalf("looking up css: %v", err)
+	}
+
+	for id, want := range map[string]bool{"night": true, "latte": true, "dusk": false} {
+		var buf bytes.Buffer
+		if err := exporter.Export(&buf, result, id, Options{}); err != nil {
+			t.Fatalf("exporting %s: %v", id, err)
+		}
+		if got := strings.Contains(buf.String(), "prefers-color-scheme"); got != want {
+			t.Errorf("%s: expected a prefers-color-scheme block %t, got %t", id, want, got)
+		}
+	}
+}
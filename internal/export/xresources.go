@@ -0,0 +1,59 @@
+package export
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+
+	"github.com/openpalettestandard/openpalette/internal/types"
+)
+
+func init() {
+	Register(xresources{})
+}
+
+// xresources writes the ANSI colors, foreground and background as X
+// resources, which most X11 terminals read.
+type xresources struct{}
+
+func (xresources) Name() string        { return "xresources" }
+func (xresources) Description() string { return "X resources for X11 terminals" }
+func (xresources) Port() string        { return "terminal" }
+
+func (xresources) FileName(variantID string) string {
+	return variantID + ".Xresources"
+}
+
+func (xresources) Export(w io.Writer, palette types.PaletteResult, variantID string, _ Options) error {
+	variant := palette.Variants[variantID]
+
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "! %s\n", variant.Name)
+	// Resources without a color are left out, as the ANSI slots are.
+	for _, resource := range []struct{ name, colorID string }{
+		{"foreground", "text"},
+		{"background", "base"},
+		{"cursorColor", "text"},
+	} {
+		if c, exists := variant.PaletteColors[resource.colorID]; exists {
+			fmt.Fprintf(&buf, "*.%s: %s\n", resource.name, c.Hex)
+		}
+	}
+
+	for _, bright := range []bool{false, true} {
+		for _, ansiName := range ansiNames {
+			ansiColor, exists := variant.AnsiPaletteColors[ansiName]
+			if !exists {
+				continue
+			}
+			v := ansiColor.Normal
+			if bright {
+				v = ansiColor.Bright
+			}
+			fmt.Fprintf(&buf, "*.color%d: %s\n", v.Code, v.Hex)
+		}
+	}
+
+	_, err := w.Write(buf.Bytes())
+	return err
+}
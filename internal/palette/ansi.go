@@ -0,0 +1,124 @@
+package palette
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/openpalettestandard/openpalette/internal/color"
+	"github.com/openpalettestandard/openpalette/internal/types"
+)
+
+// ansiNames lists the eight normal ANSI colors in code order.
+var ansiNames = []string{"black", "red", "green", "yellow", "blue", "magenta", "cyan", "white"}
+
+// IsANSISlot reports whether slot names one of the 16 ANSI colors, such as
+// "red" or "brightRed".
+func IsANSISlot(slot string) bool {
+	for _, ansiName := range ansiNames {
+		if slot == ansiName || slot == brightSlot(ansiName) {
+			return true
+		}
+	}
+	return false
+}
+
+func brightSlot(ansiName string) string {
+	return "bright" + strings.Title(ansiName)
+}
+
+// ANSIBright is the transformation from a normal to a bright ANSI color.
+// Lightness multiplies the lightness, and Chroma and Hue are added, in the
+// working space.
+type ANSIBright struct {
+	Lightness float64
+	Chroma    float64
+	Hue       float64
+}
+
+// ansiBright returns the bright transformation for a variant, starting from
+// the defaults for its polarity and working space. The chroma boost for dark
+// variants is 8 in CIE LCH, or the equivalent 0.02 in OKLCH.
+func ansiBright(rawVariant types.RawVariant, space color.Space) ANSIBright {
+	bright := ANSIBright{Lightness: 1.09, Hue: 2}
+	if rawVariant.Dark {
+		bright.Lightness = 0.94
+		bright.Chroma = 8
+		if space == color.SpaceOKLCH {
+			bright.Chroma = 0.02
+		}
+	}
+
+	if overrides := rawVariant.ANSIBright; overrides != nil {
+		if overrides.Lightness != nil {
+			bright.Lightness = *overrides.Lightness
+		}
+		if overrides.Chroma != nil {
+			bright.Chroma = *overrides.Chroma
+		}
+		if overrides.Hue != nil {
+			bright.Hue = *overrides.Hue
+		}
+	}
+	return bright
+}
+
+// ANSI256 builds the xterm 256-color palette of a variant. Codes 0-15 are the
+// variant's ANSI colors. The 6x6x6 cube (16-231) is interpolated in OKLab
+// between eight corners, base and text standing in for black and white and
+// the normal ANSI colors for the rest, and the grays (232-255) are spread
+// between base and text. Light variants therefore get a cube that runs from
+// light to dark, matching their own black and white.
+func ANSI256(variant types.PaletteVariant) []types.ANSIVariant {
+	colors := make([]types.ANSIVariant, 0, 256)
+	for _, ansiName := range ansiNames {
+		colors = append(colors, variant.AnsiPaletteColors[ansiName].Normal)
+	}
+	for _, ansiName := range ansiNames {
+		colors = append(colors, variant.AnsiPaletteColors[ansiName].Bright)
+	}
+
+	corner := func(id string) [3]float64 {
+		return color.NewColor(findColorHex(variant, id)).OKLab()
+	}
+	base, text := corner("base"), corner("text")
+
+	ansiCorner := func(ansiName string) [3]float64 {
+		return color.NewColor(variant.AnsiPaletteColors[ansiName].Normal.Hex).OKLab()
+	}
+	red, green, yellow := ansiCorner("red"), ansiCorner("green"), ansiCorner("yellow")
+	blue, magenta, cyan := ansiCorner("blue"), ansiCorner("magenta"), ansiCorner("cyan")
+
+	for r := 0; r < 6; r++ {
+		tr := float64(r) / 5
+		c0, c1 := lerpLab(base, red, tr), lerpLab(green, yellow, tr)
+		c2, c3 := lerpLab(blue, magenta, tr), lerpLab(cyan, text, tr)
+		for g := 0; g < 6; g++ {
+			tg := float64(g) / 5
+			c4, c5 := lerpLab(c0, c1, tg), lerpLab(c2, c3, tg)
+			for b := 0; b < 6; b++ {
+				code := 16 + 36*r + 6*g + b
+				colors = append(colors, labToANSIVariant(lerpLab(c4, c5, float64(b)/5), fmt.Sprintf("Color %d", code), code))
+			}
+		}
+	}
+
+	for i := 0; i < 24; i++ {
+		code := 232 + i
+		colors = append(colors, labToANSIVariant(lerpLab(base, text, float64(i+1)/25), fmt.Sprintf("Gray %d", i+1), code))
+	}
+
+	return colors
+}
+
+func lerpLab(a, b [3]float64, t float64) [3]float64 {
+	return [3]float64{a[0] + (b[0]-a[0])*t, a[1] + (b[1]-a[1])*t, a[2] + (b[2]-a[2])*t}
+}
+
+// labToANSIVariant lowers chroma as far as sRGB requires, so that the
+// interpolated colors never need gamut mapping.
+func labToANSIVariant(lab [3]float64, name string, code int) types.ANSIVariant {
+	coords := color.NewOKLab(lab[0], lab[1], lab[2]).OKLCH()
+	l := clampFloat(coords[0], 0, 1)
+	c := color.NewOKLCH(l, inGamutChroma(l, coords[1], coords[2]), coords[2])
+	return colorToANSIVariant(color.NewColor(c.ToString()), name, code)
+}
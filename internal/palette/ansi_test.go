@@ -0,0 +1,152 @@
+package palette
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/openpalettestandard/openpalette/internal/color"
+	"github.com/openpalettestandard/openpalette/internal/types"
+)
+
+const ansiConfig = `{
+	"version": "1.0.0",
+	"variants": {
+		"night": {
+			"name": "Night",
+			"dark": true,
+			"colors": {
+				"red": {"name": "Red", "hex": "#f38ba8", "accent": true},
+				"pink": {"name": "Pink", "hex": "#f5c2e7", "accent": true},
+				"mauve": {"name": "Mauve", "hex": "#cba6f7", "accent": true},
+				"text": {"name": "Text", "hex": "#cdd6f4", "accent": false},
+				"base": {"name": "Base", "hex": "#1e1e2e", "accent": false}
+			},
+			"ansi": {
+				"colors": {"magenta": "mauve", "brightMagenta": "pink", "brightBlack": "overlay0"},
+				"bright": {"lightness": 1, "chroma": 0, "hue": 0}
+			}
+		}
+	}
+}`
+
+func TestANSIOverrides(t *testing.T) {
+	result, err := GenerateFromConfig(writeConfig(t, ansiConfig), Options{})
+	if err != nil {
+		t.Fatalf("generating from config: %v", err)
+	}
+	variant := result.Variants["night"]
+	ansi := variant.AnsiPaletteColors
+
+	if got, want := ansi["magenta"].Normal.Hex, variant.PaletteColors["mauve"].Hex; got != want {
+		t.Errorf("expected magenta to use mauve %s, got %s", want, got)
+	}
+	if got, want := ansi["magenta"].Bright.Hex, variant.PaletteColors["pink"].Hex; got != want {
+		t.Errorf("expected bright magenta to use pink %s, got %s", want, got)
+	}
+	if got, want := ansi["black"].Bright.Hex, variant.PaletteColors["overlay0"].Hex; got != want {
+		t.Errorf("expected bright black to use the interpolated overlay0 %s, got %s", want, got)
+	}
+	if got, want := ansi["black"].Normal.Hex, variant.PaletteColors["surface1"].Hex; got != want {
+		t.Errorf("expected black to keep its default surface1 %s, got %s", want, got)
+	}
+	if ansi["red"].Bright.Hex != ansi["red"].Normal.Hex {
+		t.Errorf("expected an identity bright transformation, got %s and %s", ansi["red"].Normal.Hex, ansi["red"].Bright.Hex)
+	}
+}
+
+func TestANSIOverrideErrors(t *testing.T) {
+	tests := map[string]string{
+		`"colors": {"purple": "mauve"}`:  `unknown ANSI slot "purple"`,
+		`"colors": {"magenta": "lilac"}`: `unknown color "lilac"`,
+		`"bright": {"lightness": 0}`:     "must be positive",
+	}
+
+	for ansi, want := range tests {
+		config := strings.Replace(ansiConfig, `"colors": {"magenta": "mauve", "brightMagenta": "pink", "brightBlack": "overlay0"},
+				"bright": {"lightness": 1, "chroma": 0, "hue": 0}`, ansi, 1)
+
+		_, err := GenerateFromConfig(writeConfig(t, config), Options{})
+		if err == nil || !strings.Contains(err.Error(), want) {
+			t.Errorf("%s: expected an error containing %q, got %v", ansi, want, err)
+		}
+	}
+}
+
+func TestANSI256(t *testing.T) {
+	result := Generate(Options{IncludeANSI256: true})
+	variant := result.Variants["latte"]
+
+	colors := variant.ANSI256
+	if len(colors) != 256 {
+		t.Fatalf("expected 256 colors, got %d", len(colors))
+	}
+	for code, c := range colors {
+		if c.Code != code {
+			t.Errorf("entry %d has code %d", code, c.Code)
+		}
+		if code >= 16 && c.OutOfGamut {
+			t.Errorf("entry %d is out of gamut", code)
+		}
+	}
+
+	if got, want := colors[9].Hex, variant.AnsiPaletteColors["red"].Bright.Hex; got != want {
+		t.Errorf("expected code 9 to be bright red %s, got %s", want, got)
+	}
+	if got, want := colors[16].Hex, variant.PaletteColors["base"].Hex; got != want {
+		t.Errorf("expected the first cube color to be base %s, got %s", want, got)
+	}
+	if got, want := colors[231].Hex, variant.PaletteColors["text"].Hex; got != want {
+		t.Errorf("expected the last cube color to be text %s, got %s", want, got)
+	}
+	if got, want := colors[196].Hex, variant.AnsiPaletteColors["red"].Normal.Hex; got != want {
+		t.Errorf("expected the red cube corner to be red %s, got %s", want, got)
+	}
+
+	// Latte is light, so the grays darken from base towards text.
+	for code := 233; code < 256; code++ {
+		if color.NewColor(colors[code].Hex).OKLCH()[0] >= color.NewColor(colors[code-1].Hex).OKLCH()[0] {
+			t.Errorf("expected gray %d to be darker than gray %d", code, code-1)
+		}
+	}
+
+	data, err := json.Marshal(result)
+	if err != nil {
+		t.Fatalf("marshaling palette: %v", err)
+	}
+	parsed, err := types.ParseJSON(data, true)
+	if err != nil {
+		t.Fatalf("parsing palette strictly: %v", err)
+	}
+	if len(parsed.Variants["latte"].ANSI256) != 256 {
+		t.Errorf("expected ansi256 to survive a round trip")
+	}
+}
+
+func TestMissingANSIColors(t *testing.T) {
+	result, err := GenerateFromConfig(writeConfig(t, ansiConfig), Options{IncludeANSI256: true})
+	if err != nil {
+		t.Fatalf("generating from config: %v", err)
+	}
+	variant := result.Variants["night"]
+
+	for _, ansiName := range []string{"green", "yellow", "blue", "cyan"} {
+		if _, exists := variant.AnsiPaletteColors[ansiName]; exists {
+			t.Errorf("expected ANSI %s to be left out without a color to take it from", ansiName)
+		}
+	}
+	if _, exists := variant.AnsiPaletteColors["red"]; !exists {
+		t.Error("expected ANSI red to be kept")
+	}
+	if variant.ANSI256 != nil {
+		t.Error("expected no ansi256 without all eight ANSI colors")
+	}
+
+	warnings := ANSIWarnings(result)
+	if len(warnings) != 4 || !strings.Contains(warnings[0], "no color for ANSI blue") {
+		t.Errorf("expected a warning for each missing ANSI color, got %v", warnings)
+	}
+	if warnings := GamutWarnings(result); len(warnings) != 0 {
+		t.Errorf("expected no gamut warnings, got %v", warnings)
+	}
+}
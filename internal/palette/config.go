@@ -4,28 +4,171 @@ import (
 	"encoding/json"
 	"fmt"
 	"os"
+	"regexp"
+	"sort"
 
+	"github.com/openpalettestandard/openpalette/internal/color"
 	"github.com/openpalettestandard/openpalette/internal/types"
 )
 
+var hexPattern = regexp.MustCompile(`^#[0-9a-fA-F]{6}$`)
+
 type ConfigFile struct {
 	Version  string                   `json:"version"`
+	Naming   string                   `json:"naming,omitempty"`
 	Variants map[string]ConfigVariant `json:"variants"`
+
+	// variantOrder is the declaration order of Variants in the JSON file.
+	variantOrder []string
 }
 
 type ConfigVariant struct {
-	Name   string                 `json:"name"`
-	Emoji  string                 `json:"emoji"`
-	Dark   bool                   `json:"dark"`
-	Colors map[string]ConfigColor `json:"colors"`
+	Name        string                            `json:"name"`
+	Emoji       string                            `json:"emoji"`
+	Order       *int                              `json:"order,omitempty"`
+	Dark        bool                              `json:"dark"`
+	Colors      map[string]ConfigColor            `json:"colors"`
+	Translucent map[string]ConfigTranslucentColor `json:"translucent,omitempty"`
+	ANSI        *ConfigANSI                       `json:"ansi,omitempty"`
+	Ramp        *ConfigRamp                       `json:"ramp,omitempty"`
+
+	colorOrder       []string
+	translucentOrder []string
+}
+
+type ConfigTranslucentColor struct {
+	Name  string  `json:"name"`
+	From  string  `json:"from"`
+	Alpha float64 `json:"alpha"`
+}
+
+// ConfigANSI customizes the ANSI colors. Colors maps a slot, such as "magenta"
+// or "brightBlack", to the ID of the color it takes; a chromatic bright slot
+// that is not listed is derived from its normal slot using Bright.
+type ConfigANSI struct {
+	Space  string            `json:"space,omitempty"`
+	Colors map[string]string `json:"colors,omitempty"`
+	Bright *ConfigANSIBright `json:"bright,omitempty"`
+}
+
+// ConfigANSIBright overrides the bright color transformation: lightness is
+// multiplied by Lightness, and Chroma and Hue (in degrees) are added in the
+// ANSI working space.
+type ConfigANSIBright struct {
+	Lightness *float64 `json:"lightness,omitempty"`
+	Chroma    *float64 `json:"chroma,omitempty"`
+	Hue       *float64 `json:"hue,omitempty"`
+}
+
+// ConfigRamp selects the working space used to interpolate semantic elements
+// that the variant leaves out.
+type ConfigRamp struct {
+	Space string `json:"space,omitempty"`
 }
 
 type ConfigColor struct {
 	Name   string `json:"name"`
 	Hex    string `json:"hex"`
+	Order  *int   `json:"order,omitempty"`
 	Accent bool   `json:"accent"`
 }
 
+func (cf *ConfigFile) UnmarshalJSON(data []byte) error {
+	type configFileJSON ConfigFile
+	var config configFileJSON
+	if err := json.Unmarshal(data, &config); err != nil {
+		return err
+	}
+
+	var raw struct {
+		Variants json.RawMessage `json:"variants"`
+	}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+
+	order, err := types.ObjectKeys(raw.Variants)
+	if err != nil {
+		return fmt.Errorf("variants: %w", err)
+	}
+
+	*cf = ConfigFile(config)
+	cf.variantOrder = order
+	return nil
+}
+
+func (cv *ConfigVariant) UnmarshalJSON(data []byte) error {
+	type configVariantJSON ConfigVariant
+	var variant configVariantJSON
+	if err := json.Unmarshal(data, &variant); err != nil {
+		return err
+	}
+
+	var raw struct {
+		Colors      json.RawMessage `json:"colors"`
+		Translucent json.RawMessage `json:"translucent"`
+	}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+
+	colorOrder, err := types.ObjectKeys(raw.Colors)
+	if err != nil {
+		return fmt.Errorf("colors: %w", err)
+	}
+	translucentOrder, err := types.ObjectKeys(raw.Translucent)
+	if err != nil {
+		return fmt.Errorf("translucent: %w", err)
+	}
+
+	*cv = ConfigVariant(variant)
+	cv.colorOrder = colorOrder
+	cv.translucentOrder = translucentOrder
+	return nil
+}
+
+// orderedKeys sorts entries by their explicit order field where one is set
+// and by declaration position otherwise, with explicit orders winning ties.
+// Entries that were not declared in a file, such as configs built in code,
+// fall back to name order.
+func orderedKeys[T any](entries map[string]T, declared []string, explicitOrder func(T) *int) []string {
+	position := make(map[string]int, len(declared))
+	for i, key := range declared {
+		position[key] = i
+	}
+	positionOf := func(key string) int {
+		if p, exists := position[key]; exists {
+			return p
+		}
+		return len(declared)
+	}
+	rankOf := func(key string) (int, bool) {
+		if order := explicitOrder(entries[key]); order != nil {
+			return *order, true
+		}
+		return positionOf(key), false
+	}
+
+	keys := make([]string, 0, len(entries))
+	for key := range entries {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	sort.SliceStable(keys, func(i, j int) bool {
+		a, aExplicit := rankOf(keys[i])
+		b, bExplicit := rankOf(keys[j])
+		if a != b {
+			return a < b
+		}
+		if aExplicit != bExplicit {
+			return aExplicit
+		}
+		return positionOf(keys[i]) < positionOf(keys[j])
+	})
+
+	return keys
+}
+
 func LoadFromFile(filename string) ([]types.RawVariant, string, error) {
 	if filename == "" {
 		return getRawVariants(), "", nil
@@ -41,38 +184,145 @@ func LoadFromFile(filename string) ([]types.RawVariant, string, error) {
 		return nil, "", fmt.Errorf("parsing JSON config: %w", err)
 	}
 
-	return convertConfigToRawVariants(config), config.Version, nil
+	variants, err := convertConfigToRawVariants(config)
+	if err != nil {
+		return nil, "", err
+	}
+
+	return variants, config.Version, nil
 }
 
-func convertConfigToRawVariants(config ConfigFile) []types.RawVariant {
+func convertConfigToRawVariants(config ConfigFile) ([]types.RawVariant, error) {
 	var variants []types.RawVariant
 
-	for id, variant := range config.Variants {
+	if _, err := LookupNameSet(config.Naming); err != nil {
+		return nil, err
+	}
+
+	variantIDs := orderedKeys(config.Variants, config.variantOrder, func(v ConfigVariant) *int { return v.Order })
+
+	for _, id := range variantIDs {
+		variant := config.Variants[id]
 		rawVariant := types.RawVariant{
-			ID:    id,
-			Name:  variant.Name,
-			Emoji: variant.Emoji,
-			Dark:  variant.Dark,
+			ID:     id,
+			Name:   variant.Name,
+			Emoji:  variant.Emoji,
+			Dark:   variant.Dark,
+			Naming: config.Naming,
+		}
+
+		if variant.ANSI != nil {
+			switch variant.ANSI.Space {
+			case "", string(color.SpaceLCH), string(color.SpaceOKLCH):
+				rawVariant.ANSIBrightSpace = variant.ANSI.Space
+			default:
+				return nil, fmt.Errorf("variant %q: unknown ANSI working space %q (expected lch or oklch)", id, variant.ANSI.Space)
+			}
+
+			slots := make([]string, 0, len(variant.ANSI.Colors))
+			for slot := range variant.ANSI.Colors {
+				slots = append(slots, slot)
+			}
+			sort.Strings(slots)
+			for _, slot := range slots {
+				colorID := variant.ANSI.Colors[slot]
+				if !IsANSISlot(slot) {
+					return nil, fmt.Errorf("variant %q: unknown ANSI slot %q", id, slot)
+				}
+				if !definesColor(variant, colorID) {
+					return nil, fmt.Errorf("variant %q, ANSI slot %q: unknown color %q", id, slot, colorID)
+				}
+			}
+			rawVariant.ANSIColors = variant.ANSI.Colors
+
+			if bright := variant.ANSI.Bright; bright != nil {
+				if bright.Lightness != nil && *bright.Lightness <= 0 {
+					return nil, fmt.Errorf("variant %q: ANSI bright lightness factor %v must be positive", id, *bright.Lightness)
+				}
+				rawVariant.ANSIBright = &types.RawANSIBright{
+					Lightness: bright.Lightness,
+					Chroma:    bright.Chroma,
+					Hue:       bright.Hue,
+				}
+			}
 		}
 
-		for colorID, color := range variant.Colors {
-			rawVariant.PaletteColors = append(rawVariant.PaletteColors, types.RawPaletteColor{
+		if variant.Ramp != nil {
+			switch variant.Ramp.Space {
+			case "", string(color.SpaceLCH), string(color.SpaceOKLCH):
+				rawVariant.RampSpace = variant.Ramp.Space
+			default:
+				return nil, fmt.Errorf("variant %q: unknown ramp working space %q (expected lch or oklch)", id, variant.Ramp.Space)
+			}
+		}
+
+		colorIDs := orderedKeys(variant.Colors, variant.colorOrder, func(c ConfigColor) *int { return c.Order })
+
+		for _, colorID := range colorIDs {
+			configColor := variant.Colors[colorID]
+			rawColor := types.RawPaletteColor{
 				ID:     colorID,
-				Name:   color.Name,
-				Hex:    color.Hex,
-				Accent: color.Accent,
+				Name:   configColor.Name,
+				Hex:    configColor.Hex,
+				Accent: configColor.Accent,
+			}
+
+			parsed, err := color.Parse(configColor.Hex)
+			if err != nil {
+				return nil, fmt.Errorf("variant %q, color %q: %w", id, colorID, err)
+			}
+			rawColor.Hex = parsed.ToString()
+			if !hexPattern.MatchString(configColor.Hex) {
+				rawColor.Source = configColor.Hex
+			}
+
+			rawVariant.PaletteColors = append(rawVariant.PaletteColors, rawColor)
+		}
+
+		translucentIDs := orderedKeys(variant.Translucent, variant.translucentOrder, func(ConfigTranslucentColor) *int { return nil })
+
+		for _, translucentID := range translucentIDs {
+			translucent := variant.Translucent[translucentID]
+			if _, exists := variant.Colors[translucentID]; exists {
+				return nil, fmt.Errorf("variant %q, translucent color %q: name is already used by a color", id, translucentID)
+			}
+			if !definesColor(variant, translucent.From) {
+				return nil, fmt.Errorf("variant %q, translucent color %q: unknown source color %q", id, translucentID, translucent.From)
+			}
+			if translucent.Alpha < 0 || translucent.Alpha > 1 {
+				return nil, fmt.Errorf("variant %q, translucent color %q: alpha %v is outside 0-1", id, translucentID, translucent.Alpha)
+			}
+
+			rawVariant.TranslucentColors = append(rawVariant.TranslucentColors, types.RawTranslucentColor{
+				ID:    translucentID,
+				Name:  translucent.Name,
+				From:  translucent.From,
+				Alpha: translucent.Alpha,
 			})
 		}
 
 		variants = append(variants, rawVariant)
 	}
 
-	return variants
+	return variants, nil
+}
+
+// definesColor reports whether colorID will exist in the generated variant,
+// either because it is listed or because it is interpolated into the ramp.
+func definesColor(variant ConfigVariant, colorID string) bool {
+	if _, exists := variant.Colors[colorID]; exists {
+		return true
+	}
+	_, semantic := rampPositions[colorID]
+	_, hasBase := variant.Colors["base"]
+	_, hasText := variant.Colors["text"]
+	return semantic && hasBase && hasText
 }
 
 func GenerateExampleConfig(filename string) error {
 	config := ConfigFile{
 		Version: "1.0.0",
+		Naming:  string(DefaultNaming),
 		Variants: map[string]ConfigVariant{
 			"latte": {
 				Name:  "Latte",
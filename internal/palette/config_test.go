@@ -0,0 +1,118 @@
+package palette
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+const orderedConfig = `{
+	"version": "1.0.0",
+	"variants": {
+		"night": {
+			"name": "Night",
+			"dark": true,
+			"colors": {
+				"text": {"name": "Text", "hex": "#cdd6f4", "accent": false},
+				"base": {"name": "Base", "hex": "#1e1e2e", "accent": false},
+				"red": {"name": "Red", "hex": "#f38ba8", "accent": true},
+				"blue": {"name": "Blue", "hex": "#89b4fa", "accent": true},
+				"green": {"name": "Green", "hex": "#a6e3a1", "accent": true}
+			},
+			"translucent": {
+				"veil": {"name": "Veil", "from": "surface2", "alpha": 0.5}
+			}
+		},
+		"day": {
+			"name": "Day",
+			"dark": false,
+			"colors": {
+				"green": {"name": "Green", "hex": "#40a02b", "accent": true, "order": 2},
+				"red": {"name": "Red", "hex": "#d20f39", "accent": true, "order": 0},
+				"blue": {"name": "Blue", "hex": "#1e66f5", "accent": true, "order": 1}
+			}
+		},
+		"dusk": {
+			"name": "Dusk",
+			"order": 0,
+			"dark": true,
+			"colors": {
+				"base": {"name": "Base", "hex": "#303446", "accent": false}
+			}
+		}
+	}
+}`
+
+func writeConfig(t *testing.T, content string) string {
+	t.Helper()
+
+	filename := filepath.Join(t.TempDir(), "config.json")
+	if err := os.WriteFile(filename, []byte(content), 0644); err != nil {
+		t.Fatalf("writing config: %v", err)
+	}
+	return filename
+}
+
+func TestGenerateFromConfigIsDeterministic(t *testing.T) {
+	filename := writeConfig(t, orderedConfig)
+
+	var first []byte
+	for i := 0; i < 10; i++ {
+		result, err := GenerateFromConfig(filename, Options{IncludeOKLCH: true})
+		if err != nil {
+			t.Fatalf("generating from config: %v", err)
+		}
+
+		data, err := json.MarshalIndent(result, "", "  ")
+		if err != nil {
+			t.Fatalf("marshaling palette: %v", err)
+		}
+
+		if first == nil {
+			first = data
+		} else if !bytes.Equal(first, data) {
+			t.Fatalf("run %d produced different output", i)
+		}
+	}
+}
+
+func TestConfigOrder(t *testing.T) {
+	result, err := GenerateFromConfig(writeConfig(t, orderedConfig), Options{})
+	if err != nil {
+		t.Fatalf("generating from config: %v", err)
+	}
+
+	variantOrder := map[string]int{"dusk": 0, "night": 1, "day": 2}
+	for id, want := range variantOrder {
+		if got := result.Variants[id].Order; got != want {
+			t.Errorf("variant %s: expected order %d, got %d", id, want, got)
+		}
+	}
+
+	colorOrder := map[string]map[string]int{
+		"night": {"text": 0, "base": 1, "red": 2, "blue": 3, "green": 4},
+		"day":   {"red": 0, "blue": 1, "green": 2},
+	}
+	for variantID, colors := range colorOrder {
+		for colorID, want := range colors {
+			if got := result.Variants[variantID].PaletteColors[colorID].Order; got != want {
+				t.Errorf("%s.%s: expected order %d, got %d", variantID, colorID, want, got)
+			}
+		}
+	}
+}
+
+func TestTranslucentFromInterpolatedColor(t *testing.T) {
+	result, err := GenerateFromConfig(writeConfig(t, orderedConfig), Options{})
+	if err != nil {
+		t.Fatalf("generating from config: %v", err)
+	}
+
+	night := result.Variants["night"]
+	veil, exists := night.TranslucentColors["veil"]
+	if !exists || veil.Hex != night.PaletteColors["surface2"].Hex+"80" {
+		t.Errorf("expected veil to be the interpolated surface2 at half alpha, got %+v", veil)
+	}
+}
@@ -0,0 +1,235 @@
+package palette
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/openpalettestandard/openpalette/internal/color"
+	"github.com/openpalettestandard/openpalette/internal/types"
+)
+
+// contrastTargets are the section 6.1 colors that must or should reach WCAG
+// AA against base. Accents are added to these in Derive.
+var contrastTargets = map[string]bool{
+	"text":     true,
+	"subtext1": true,
+	"subtext0": true,
+}
+
+const deriveContrast = 4.5
+
+// minAccentDistance is the CIEDE2000 distance derived accents are kept apart
+// by, the default threshold of the cvd check.
+const minAccentDistance = 5.0
+
+// Derive produces the opposite-polarity counterpart of a variant as a config
+// variant. Base and text move to the middle of the lightness ranges of the
+// new polarity and the rest of the semantic ramp is interpolated between
+// them again. Accents keep their hue while their lightness is moved just far
+// enough to reach WCAG AA against the new base and to stay apart from each
+// other. Any other color keeps its place on the line from base to text.
+func Derive(rawVariant types.RawVariant) (ConfigVariant, error) {
+	rawColors := FillSemanticRamp(rawVariant.PaletteColors, rawVariant.Dark, color.SpaceOKLCH)
+
+	colors := make(map[string]*color.Color, len(rawColors))
+	for _, rawColor := range rawColors {
+		colors[rawColor.ID] = sourceColor(rawColor)
+	}
+
+	base, hasBase := colors["base"]
+	text, hasText := colors["text"]
+	if !hasBase || !hasText {
+		return ConfigVariant{}, fmt.Errorf("variant %q: deriving needs both base and text", rawVariant.ID)
+	}
+
+	dark := !rawVariant.Dark
+	baseRange, textRange := neutralRanges(dark)
+	newBase := withLightness(base, (baseRange[0]+baseRange[1])/2)
+	newText := withLightness(text, (textRange[0]+textRange[1])/2)
+
+	rampSpace := color.SpaceOKLCH
+	if rawVariant.RampSpace != "" {
+		rampSpace = color.Space(rawVariant.RampSpace)
+	}
+	ramp := make(map[string]*color.Color, len(semanticNames))
+	for _, rawColor := range FillSemanticRamp([]types.RawPaletteColor{
+		{ID: "text", Hex: newText.ToString()},
+		{ID: "base", Hex: newBase.ToString()},
+	}, dark, rampSpace) {
+		ramp[rawColor.ID] = color.NewColor(rawColor.Hex)
+	}
+
+	oldBaseL, oldTextL := base.OKLCH()[0], text.OKLCH()[0]
+	newBaseL, newTextL := newBase.OKLCH()[0], newText.OKLCH()[0]
+	alongRamp := func(c *color.Color) *color.Color {
+		if oldTextL == oldBaseL {
+			return withLightness(c, newBaseL)
+		}
+		t := (c.OKLCH()[0] - oldBaseL) / (oldTextL - oldBaseL)
+		return withLightness(c, clampFloat(newBaseL+t*(newTextL-newBaseL), 0, 1))
+	}
+
+	lightBase := !dark
+	variant := ConfigVariant{
+		Name:   deriveName(rawVariant),
+		Emoji:  rawVariant.Emoji,
+		Dark:   dark,
+		Colors: make(map[string]ConfigColor, len(rawColors)),
+	}
+
+	var accents []*color.Color
+	for i, rawColor := range rawColors {
+		var derived *color.Color
+		switch rampColor, semantic := ramp[rawColor.ID]; {
+		case rawColor.Accent:
+			// Accents start from their original lightness, since they sit
+			// on both kinds of background.
+			derived = tuneContrast(colors[rawColor.ID], newBase, lightBase)
+			derived = separateAccent(derived, newBase, lightBase, accents)
+			accents = append(accents, derived)
+		case semantic:
+			derived = rampColor
+			if contrastTargets[rawColor.ID] {
+				derived = tuneContrast(derived, newBase, lightBase)
+			}
+		default:
+			derived = alongRamp(colors[rawColor.ID])
+		}
+
+		order := i
+		variant.Colors[rawColor.ID] = ConfigColor{
+			Name:   rawColor.Name,
+			Hex:    derived.ToString(),
+			Order:  &order,
+			Accent: rawColor.Accent,
+		}
+	}
+
+	if len(rawVariant.TranslucentColors) > 0 {
+		variant.Translucent = make(map[string]ConfigTranslucentColor, len(rawVariant.TranslucentColors))
+		for _, rawTranslucent := range rawVariant.TranslucentColors {
+			variant.Translucent[rawTranslucent.ID] = ConfigTranslucentColor{
+				Name:  rawTranslucent.Name,
+				From:  rawTranslucent.From,
+				Alpha: rawTranslucent.Alpha,
+			}
+		}
+	}
+	// Bright parameters are tuned for one polarity, so only the working
+	// space and slot colors carry over.
+	if rawVariant.ANSIBrightSpace != "" || len(rawVariant.ANSIColors) > 0 {
+		variant.ANSI = &ConfigANSI{Space: rawVariant.ANSIBrightSpace, Colors: rawVariant.ANSIColors}
+	}
+	if rawVariant.RampSpace != "" {
+		variant.Ramp = &ConfigRamp{Space: rawVariant.RampSpace}
+	}
+
+	return variant, nil
+}
+
+func deriveName(rawVariant types.RawVariant) string {
+	if rawVariant.Dark {
+		return rawVariant.Name + " Light"
+	}
+	return rawVariant.Name + " Dark"
+}
+
+// tuneContrast keeps the hue of c and moves its OKLCH lightness the least
+// distance that reaches deriveContrast against base, lowering chroma only as
+// far as sRGB requires. Lightness goes down on a light base and up on a dark
+// one.
+func tuneContrast(c, base *color.Color, lightBase bool) *color.Color {
+	coords := c.OKLCH()
+	if color.ContrastRatio(withLightness(c, coords[0]), base) >= deriveContrast {
+		return withLightness(c, coords[0])
+	}
+
+	near, far := coords[0], 1.0
+	if lightBase {
+		far = 0
+	}
+	if color.ContrastRatio(withLightness(c, far), base) < deriveContrast {
+		return withLightness(c, far)
+	}
+
+	for i := 0; i < 32 && math.Abs(far-near) > 1e-4; i++ {
+		mid := (near + far) / 2
+		if color.ContrastRatio(withLightness(c, mid), base) >= deriveContrast {
+			far = mid
+		} else {
+			near = mid
+		}
+	}
+	return withLightness(c, far)
+}
+
+// separateAccent moves the lightness of accent the least distance, trying
+// away from base first, that puts it at least minAccentDistance from every
+// accent in placed while keeping deriveContrast against base. The accent is
+// returned as it is when no such lightness exists.
+func separateAccent(accent, base *color.Color, lightBase bool, placed []*color.Color) *color.Color {
+	distinct := func(c *color.Color) bool {
+		for _, other := range placed {
+			if color.DeltaE2000(c, other) < minAccentDistance {
+				return false
+			}
+		}
+		return true
+	}
+	if distinct(accent) {
+		return accent
+	}
+
+	l := accent.OKLCH()[0]
+	away := 1.0
+	if lightBase {
+		away = -1
+	}
+	for i := 1; i <= 100; i++ {
+		step := float64(i) / 100
+		for _, candidateL := range []float64{l + away*step, l - away*step} {
+			if candidateL < 0 || candidateL > 1 {
+				continue
+			}
+			candidate := withLightness(accent, candidateL)
+			if color.ContrastRatio(candidate, base) >= deriveContrast && distinct(candidate) {
+				return candidate
+			}
+		}
+	}
+	return accent
+}
+
+// withLightness returns c with its OKLCH lightness set to l, keeping the hue
+// and lowering chroma only as far as sRGB requires. The result is rounded
+// through the hex the config will contain, so that contrast measured on it
+// cannot change when the config is read back.
+func withLightness(c *color.Color, l float64) *color.Color {
+	coords := c.OKLCH()
+	tuned := color.NewOKLCH(l, inGamutChroma(l, coords[1], coords[2]), coords[2])
+	tuned.SetAlpha(c.Alpha())
+	return color.NewColor(tuned.ToString())
+}
+
+// inGamutChroma lowers chroma until the color fits in sRGB. Unlike the CSS
+// gamut mapping used for hex output, it never clips, so the hue is kept.
+func inGamutChroma(l, c, h float64) float64 {
+	if color.NewOKLCH(l, c, h).InGamut() {
+		return c
+	}
+
+	low, high := 0.0, c
+	for high-low > 1e-5 {
+		mid := (low + high) / 2
+		if color.NewOKLCH(l, mid, h).InGamut() {
+			low = mid
+		} else {
+			high = mid
+		}
+	}
+	return low
+}
+
+func clampFloat(value, min, max float64) float64 {
+	return math.Max(min, math.Min(max, value))
+}
@@ -0,0 +1,102 @@
+package palette
+
+import (
+	"math"
+	"testing"
+
+	"github.com/openpalettestandard/openpalette/internal/color"
+)
+
+func TestDerive(t *testing.T) {
+	latte := getRawVariants()[0]
+
+	derived, err := Derive(latte)
+	if err != nil {
+		t.Fatalf("deriving from latte: %v", err)
+	}
+	if !derived.Dark {
+		t.Error("expected a dark variant from a light one")
+	}
+	if len(derived.Colors) != len(latte.PaletteColors) {
+		t.Errorf("expected %d colors, got %d", len(latte.PaletteColors), len(derived.Colors))
+	}
+
+	base := color.NewColor(derived.Colors["base"].Hex)
+	text := color.NewColor(derived.Colors["text"].Hex)
+	if base.OKLCH()[0] >= text.OKLCH()[0] {
+		t.Errorf("expected base %s to be darker than text %s", base.ToString(), text.ToString())
+	}
+
+	for _, rawColor := range latte.PaletteColors {
+		c := derived.Colors[rawColor.ID]
+		if !c.Accent && !contrastTargets[rawColor.ID] {
+			continue
+		}
+
+		derivedColor := color.NewColor(c.Hex)
+		if ratio := color.ContrastRatio(derivedColor, base); ratio < deriveContrast {
+			t.Errorf("%s: contrast %.2f against the derived base is below %.1f", rawColor.ID, ratio, deriveContrast)
+		}
+
+		if c.Accent {
+			original, hue := color.NewColor(rawColor.Hex).OKLCH()[2], derivedColor.OKLCH()[2]
+			if diff := math.Abs(math.Mod(hue-original+540, 360) - 180); diff > 5 {
+				t.Errorf("%s: hue moved from %.1f to %.1f", rawColor.ID, original, hue)
+			}
+		}
+	}
+}
+
+func TestDeriveRampAndAccents(t *testing.T) {
+	// Derive a dark variant from latte, then a light one back from it.
+	rawVariant := getRawVariants()[0]
+	for round := 0; round < 2; round++ {
+		derived, err := Derive(rawVariant)
+		if err != nil {
+			t.Fatalf("deriving from %s: %v", rawVariant.ID, err)
+		}
+
+		lightness := func(id string) float64 {
+			return color.NewColor(derived.Colors[id].Hex).OKLCH()[0]
+		}
+
+		baseRange, _ := neutralRanges(derived.Dark)
+		if l := lightness("base"); l < baseRange[0] || l > baseRange[1] {
+			t.Errorf("%s: base lightness %.2f is outside %v", rawVariant.ID, l, baseRange)
+		}
+
+		// From text down to surface0 the ramp approaches base; mantle and
+		// crust are darker than base in both polarities.
+		ramp := semanticNames[:len(semanticNames)-2]
+		for i := 1; i < len(ramp); i++ {
+			closer := math.Abs(lightness(ramp[i])-lightness("base")) < math.Abs(lightness(ramp[i-1])-lightness("base"))
+			if !closer {
+				t.Errorf("%s: expected %s to be closer to base than %s", rawVariant.ID, ramp[i], ramp[i-1])
+			}
+		}
+		if lightness("mantle") >= lightness("base") || lightness("crust") >= lightness("mantle") {
+			t.Errorf("%s: expected base, mantle and crust to darken in turn", rawVariant.ID)
+		}
+
+		var accents []string
+		for _, rawColor := range rawVariant.PaletteColors {
+			if rawColor.Accent {
+				accents = append(accents, rawColor.ID)
+			}
+		}
+		for i, a := range accents {
+			for _, b := range accents[i+1:] {
+				ca, cb := color.NewColor(derived.Colors[a].Hex), color.NewColor(derived.Colors[b].Hex)
+				if distance := color.DeltaE2000(ca, cb); distance < minAccentDistance {
+					t.Errorf("%s: %s %s and %s %s are only %.2f apart", rawVariant.ID, a, ca.ToString(), b, cb.ToString(), distance)
+				}
+			}
+		}
+
+		rawVariants, err := convertConfigToRawVariants(ConfigFile{Variants: map[string]ConfigVariant{"derived": derived}})
+		if err != nil {
+			t.Fatalf("converting derived variant: %v", err)
+		}
+		rawVariant = rawVariants[0]
+	}
+}
@@ -0,0 +1,287 @@
+package palette
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/openpalettestandard/openpalette/internal/color"
+	"github.com/openpalettestandard/openpalette/internal/types"
+)
+
+// DTCGExtension is the $extensions key under which DTCG files carry variant
+// metadata that the format has no field for.
+const DTCGExtension = "org.openpalette"
+
+// dtcgMaxAliasDepth bounds alias chains, so that cycles are reported rather
+// than followed forever.
+const dtcgMaxAliasDepth = 16
+
+// dtcgGroups are top-level group names that classify the colors inside them
+// instead of being part of their IDs.
+var dtcgGroups = map[string]bool{
+	"accent":   true,
+	"accents":  true,
+	"semantic": true,
+	"color":    true,
+	"colors":   true,
+}
+
+// dtcgSkipped are the groups that the generator derives from the other
+// colors, so they are not imported.
+var dtcgSkipped = map[string]bool{
+	"ansi":        true,
+	"translucent": true,
+}
+
+type dtcgToken struct {
+	path        []string
+	tokenType   string
+	value       json.RawMessage
+	description string
+}
+
+type dtcgMetadata struct {
+	ID    string `json:"id"`
+	Name  string `json:"name"`
+	Emoji string `json:"emoji"`
+	Dark  *bool  `json:"dark"`
+}
+
+// ImportDTCG reads W3C Design Tokens Community Group files into a config
+// with one variant per file, in the order given.
+func ImportDTCG(filenames []string) (ConfigFile, error) {
+	config := ConfigFile{
+		Version:  "1.0.0",
+		Variants: make(map[string]ConfigVariant, len(filenames)),
+	}
+
+	var allIDs []string
+	for i, filename := range filenames {
+		data, err := os.ReadFile(filename)
+		if err != nil {
+			return ConfigFile{}, fmt.Errorf("reading tokens file: %w", err)
+		}
+
+		fallbackID := strings.TrimSuffix(strings.TrimSuffix(filepath.Base(filename), ".json"), ".tokens")
+		id, variant, err := ParseDTCG(data, fallbackID)
+		if err != nil {
+			return ConfigFile{}, fmt.Errorf("%s: %w", filename, err)
+		}
+		if _, exists := config.Variants[id]; exists {
+			return ConfigFile{}, fmt.Errorf("%s: variant %q is already defined", filename, id)
+		}
+
+		order := i
+		variant.Order = &order
+		config.Variants[id] = variant
+		for colorID := range variant.Colors {
+			allIDs = append(allIDs, colorID)
+		}
+	}
+
+	config.Naming = string(DetectNameSet(allIDs).Naming)
+	return config, nil
+}
+
+// ParseDTCG turns the color tokens of one DTCG document into a config
+// variant. Tokens in an accent or semantic group are classified by it, and
+// others by whether their ID is a semantic element; aliases are resolved.
+// The ID, name and polarity come from the document's metadata when it has
+// any, falling back to fallbackID and the lightness of base.
+func ParseDTCG(data []byte, fallbackID string) (string, ConfigVariant, error) {
+	var tokens []dtcgToken
+	if err := collectDTCGTokens(data, nil, "", &tokens); err != nil {
+		return "", ConfigVariant{}, err
+	}
+
+	byPath := make(map[string]dtcgToken, len(tokens))
+	for _, token := range tokens {
+		byPath[strings.Join(token.path, ".")] = token
+	}
+
+	variant := ConfigVariant{Colors: make(map[string]ConfigColor)}
+	for _, token := range tokens {
+		if token.tokenType != "color" || dtcgSkipped[token.path[0]] {
+			continue
+		}
+
+		parsed, err := resolveDTCGColor(token, byPath, 0)
+		if err != nil {
+			return "", ConfigVariant{}, fmt.Errorf("token %s: %w", strings.Join(token.path, "."), err)
+		}
+
+		group, path := "", token.path
+		if dtcgGroups[path[0]] && len(path) > 1 {
+			group, path = path[0], path[1:]
+		}
+		id := strings.ToLower(strings.Join(path, "-"))
+
+		var accent bool
+		switch group {
+		case "accent", "accents":
+			accent = true
+		case "semantic":
+			accent = false
+		default:
+			_, semantic := rampPositions[id]
+			accent = !semantic
+		}
+
+		if _, exists := variant.Colors[id]; exists {
+			return "", ConfigVariant{}, fmt.Errorf("token %s: color %q is already defined", strings.Join(token.path, "."), id)
+		}
+
+		name := token.description
+		if name == "" {
+			name = displayName(id)
+		}
+		order := len(variant.Colors)
+		variant.Colors[id] = ConfigColor{
+			Name:   name,
+			Hex:    parsed.ToString(),
+			Order:  &order,
+			Accent: accent,
+		}
+	}
+	if len(variant.Colors) == 0 {
+		return "", ConfigVariant{}, fmt.Errorf("no color tokens found")
+	}
+
+	var root struct {
+		Description string                     `json:"$description"`
+		Extensions  map[string]json.RawMessage `json:"$extensions"`
+	}
+	if err := json.Unmarshal(data, &root); err != nil {
+		return "", ConfigVariant{}, err
+	}
+	var metadata dtcgMetadata
+	if raw, exists := root.Extensions[DTCGExtension]; exists {
+		if err := json.Unmarshal(raw, &metadata); err != nil {
+			return "", ConfigVariant{}, fmt.Errorf("$extensions.%s: %w", DTCGExtension, err)
+		}
+	}
+
+	id := metadata.ID
+	if id == "" {
+		id = fallbackID
+	}
+	variant.Name = metadata.Name
+	if variant.Name == "" {
+		variant.Name = root.Description
+	}
+	if variant.Name == "" {
+		variant.Name = displayName(id)
+	}
+	variant.Emoji = metadata.Emoji
+	if metadata.Dark != nil {
+		variant.Dark = *metadata.Dark
+	} else if base, exists := variant.Colors["base"]; exists {
+		variant.Dark = color.NewColor(base.Hex).OKLCH()[0] < 0.5
+	}
+
+	return id, variant, nil
+}
+
+// collectDTCGTokens appends the tokens in data to tokens in document order.
+// Tokens inherit $type from the closest group that sets it.
+func collectDTCGTokens(data json.RawMessage, path []string, inheritedType string, tokens *[]dtcgToken) error {
+	var members map[string]json.RawMessage
+	if err := json.Unmarshal(data, &members); err != nil {
+		return fmt.Errorf("%s: expected a group or token: %w", strings.Join(path, "."), err)
+	}
+
+	tokenType := inheritedType
+	if raw, exists := members["$type"]; exists {
+		if err := json.Unmarshal(raw, &tokenType); err != nil {
+			return fmt.Errorf("%s: $type: %w", strings.Join(path, "."), err)
+		}
+	}
+
+	if value, exists := members["$value"]; exists {
+		if len(path) == 0 {
+			return fmt.Errorf("the root cannot be a token")
+		}
+
+		token := dtcgToken{path: path, tokenType: tokenType, value: value}
+		if raw, exists := members["$description"]; exists {
+			if err := json.Unmarshal(raw, &token.description); err != nil {
+				return fmt.Errorf("%s: $description: %w", strings.Join(path, "."), err)
+			}
+		}
+		*tokens = append(*tokens, token)
+		return nil
+	}
+
+	keys, err := types.ObjectKeys(data)
+	if err != nil {
+		return err
+	}
+	for _, key := range keys {
+		if strings.HasPrefix(key, "$") {
+			continue
+		}
+		childPath := append(append([]string(nil), path...), key)
+		if err := collectDTCGTokens(members[key], childPath, tokenType, tokens); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// resolveDTCGColor parses the value of a color token, following aliases such
+// as "{accent.red}". Values are either CSS color strings or DTCG color
+// objects in srgb or oklch.
+func resolveDTCGColor(token dtcgToken, byPath map[string]dtcgToken, depth int) (*color.Color, error) {
+	var text string
+	if err := json.Unmarshal(token.value, &text); err == nil {
+		if strings.HasPrefix(text, "{") && strings.HasSuffix(text, "}") {
+			if depth >= dtcgMaxAliasDepth {
+				return nil, fmt.Errorf("alias chain is too long or circular")
+			}
+			target, exists := byPath[strings.Trim(text, "{}")]
+			if !exists {
+				return nil, fmt.Errorf("unknown alias %s", text)
+			}
+			return resolveDTCGColor(target, byPath, depth+1)
+		}
+		return color.Parse(text)
+	}
+
+	var value struct {
+		ColorSpace string     `json:"colorSpace"`
+		Components [3]float64 `json:"components"`
+		Alpha      *float64   `json:"alpha"`
+		Hex        string     `json:"hex"`
+	}
+	if err := json.Unmarshal(token.value, &value); err != nil {
+		return nil, fmt.Errorf("expected a color string or object: %w", err)
+	}
+
+	var parsed *color.Color
+	switch {
+	case value.ColorSpace == "srgb":
+		parsed = color.NewColor(fmt.Sprintf("%02x%02x%02x",
+			channel(value.Components[0]), channel(value.Components[1]), channel(value.Components[2])))
+	case value.ColorSpace == "oklch":
+		parsed = color.NewOKLCH(value.Components[0], value.Components[1], value.Components[2])
+	case value.Hex != "":
+		var err error
+		if parsed, err = color.Parse(value.Hex); err != nil {
+			return nil, err
+		}
+	default:
+		return nil, fmt.Errorf("unsupported color space %q (expected srgb or oklch)", value.ColorSpace)
+	}
+
+	if value.Alpha != nil {
+		parsed.SetAlpha(*value.Alpha)
+	}
+	return parsed, nil
+}
+
+func channel(value float64) int {
+	return int(clampFloat(value, 0, 1)*255 + 0.5)
+}
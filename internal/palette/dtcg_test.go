@@ -0,0 +1,77 @@
+package palette
+
+import (
+	"strings"
+	"testing"
+)
+
+const tokensStudioDocument = `{
+	"color": {
+		"$type": "color",
+		"red": {"$value": "#d20f39", "$description": "Brand Red"},
+		"blue": {"$value": {"colorSpace": "srgb", "components": [0.118, 0.4, 0.961], "alpha": 1}},
+		"primary": {"$value": "{color.blue}"},
+		"base": {"$value": "#1e1e2e"},
+		"text": {"$value": {"colorSpace": "oklch", "components": [0.88, 0.04, 275]}}
+	},
+	"ansi": {
+		"$type": "color",
+		"black": {"$value": "#000000"}
+	},
+	"spacing": {
+		"small": {"$type": "dimension", "$value": "4px"}
+	}
+}`
+
+func TestParseDTCG(t *testing.T) {
+	id, variant, err := ParseDTCG([]byte(tokensStudioDocument), "brand")
+	if err != nil {
+		t.Fatalf("parsing tokens: %v", err)
+	}
+
+	if id != "brand" || variant.Name != "Brand" || !variant.Dark {
+		t.Errorf("expected a dark variant brand named Brand, got %q %q dark %t", id, variant.Name, variant.Dark)
+	}
+
+	want := map[string]struct {
+		name   string
+		hex    string
+		accent bool
+		order  int
+	}{
+		"red":     {"Brand Red", "#d20f39", true, 0},
+		"blue":    {"Blue", "#1e66f5", true, 1},
+		"primary": {"Primary", "#1e66f5", true, 2},
+		"base":    {"Base", "#1e1e2e", false, 3},
+	}
+	for colorID, w := range want {
+		got, exists := variant.Colors[colorID]
+		if !exists {
+			t.Errorf("%s: missing", colorID)
+			continue
+		}
+		if got.Name != w.name || got.Hex != w.hex || got.Accent != w.accent || *got.Order != w.order {
+			t.Errorf("%s: expected %+v, got %+v (order %d)", colorID, w, got, *got.Order)
+		}
+	}
+	if len(variant.Colors) != 5 {
+		t.Errorf("expected ANSI and non-color tokens to be skipped, got %d colors", len(variant.Colors))
+	}
+}
+
+func TestParseDTCGErrors(t *testing.T) {
+	tests := map[string]string{
+		`{"a": {"$type": "color", "$value": "{b}"}, "b": {"$type": "color", "$value": "{a}"}}`: "circular",
+		`{"a": {"$type": "color", "$value": "{missing}"}}`:                                     "unknown alias",
+		`{"a": {"$type": "color", "$value": {"colorSpace": "hsl", "components": [0, 0, 0]}}}`:  "unsupported color space",
+		`{"spacing": {"$type": "dimension", "$value": "4px"}}`:                                 "no color tokens",
+		`{"a": {"$type": "color", "$value": "#ffffff", "$description": 1}}`:                    "a: $description",
+	}
+
+	for document, want := range tests {
+		_, _, err := ParseDTCG([]byte(document), "test")
+		if err == nil || !strings.Contains(err.Error(), want) {
+			t.Errorf("%s: expected an error containing %q, got %v", document, want, err)
+		}
+	}
+}
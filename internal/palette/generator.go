@@ -1,36 +1,54 @@
 package palette
 
 import (
+	"fmt"
 	"math"
+	"sort"
 	"strings"
 
 	"github.com/openpalettestandard/openpalette/internal/color"
 	"github.com/openpalettestandard/openpalette/internal/types"
 )
 
-func Generate() types.PaletteResult {
+type Options struct {
+	IncludeOKLCH     bool
+	IncludeWideGamut bool
+	IncludeANSI256   bool
+
+	// Naming overrides the naming scheme chosen by the config.
+	Naming string
+}
+
+func Generate(opts Options) types.PaletteResult {
 	rawVariants, _, _ := LoadFromFile("")
-	return GenerateFromVariants(rawVariants, "")
+	return GenerateFromVariants(rawVariants, "", opts)
 }
 
-func GenerateFromConfig(configFile string) (types.PaletteResult, error) {
+func GenerateFromConfig(configFile string, opts Options) (types.PaletteResult, error) {
 	rawVariants, configVersion, err := LoadFromFile(configFile)
 	if err != nil {
 		return types.PaletteResult{}, err
 	}
 
-	return GenerateFromVariants(rawVariants, configVersion), nil
+	return GenerateFromVariants(rawVariants, configVersion, opts), nil
 }
 
-func GenerateFromVariants(rawVariants []types.RawVariant, version string) types.PaletteResult {
-	ansiMappings := getANSIMappings()
-
+func GenerateFromVariants(rawVariants []types.RawVariant, version string, opts Options) types.PaletteResult {
 	result := types.PaletteResult{
 		Version:  version,
 		Variants: make(map[string]types.PaletteVariant),
 	}
 
 	for variantIndex, rawVariant := range rawVariants {
+		naming := rawVariant.Naming
+		if opts.Naming != "" {
+			naming = opts.Naming
+		}
+		names, err := LookupNameSet(naming)
+		if err != nil {
+			names = defaultNameSet()
+		}
+
 		variant := types.PaletteVariant{
 			Name:              rawVariant.Name,
 			Emoji:             rawVariant.Emoji,
@@ -40,21 +58,165 @@ func GenerateFromVariants(rawVariants []types.RawVariant, version string) types.
 			AnsiPaletteColors: make(map[string]types.ANSIColor),
 		}
 
+		rampSpace := color.SpaceOKLCH
+		if rawVariant.RampSpace != "" {
+			rampSpace = color.Space(rawVariant.RampSpace)
+		}
+		// Colors keep the order they were declared in; interpolated semantic
+		// elements follow them.
+		declared := make(map[string]int, len(rawVariant.PaletteColors))
 		for colorIndex, rawColor := range rawVariant.PaletteColors {
-			variant.PaletteColors[rawColor.ID] = ProcessColor(rawColor, colorIndex)
+			declared[rawColor.ID] = colorIndex
+		}
+		rawVariant.PaletteColors = FillSemanticRamp(rawVariant.PaletteColors, rawVariant.Dark, rampSpace)
+
+		renamed := canonicalIDs(rawVariant.PaletteColors, names)
+		candidates := ansiCandidates(rawVariant.PaletteColors, renamed, names)
+
+		interpolated := len(declared)
+		for _, rawColor := range rawVariant.PaletteColors {
+			colorIndex, exists := declared[rawColor.ID]
+			if !exists {
+				colorIndex = interpolated
+				interpolated++
+			}
+
+			id := renamed[rawColor.ID]
+			if id != rawColor.ID && rawColor.Name == displayName(rawColor.ID) {
+				rawColor.Name = displayName(id)
+			}
+			rawColor.ID = id
+
+			paletteColor := ProcessColor(rawColor, colorIndex)
+			paletteColor.Extension = !names.IsStandard(id)
+			if opts.IncludeWideGamut {
+				addWideGamut(&paletteColor, rawColor)
+			}
+			variant.PaletteColors[id] = paletteColor
 		}
+		variant.ColorOrder = colorOrder(names, variant.PaletteColors)
 
-		for ansiIndex, ansiName := range []string{"black", "red", "green", "yellow", "blue", "magenta", "cyan", "white"} {
+		for translucentIndex, rawTranslucent := range rawVariant.TranslucentColors {
+			if variant.TranslucentColors == nil {
+				variant.TranslucentColors = make(map[string]types.PaletteColor)
+			}
+			rawTranslucent.From = renamed[rawTranslucent.From]
+			variant.TranslucentColors[rawTranslucent.ID] = ProcessTranslucentColor(rawTranslucent, variant, translucentIndex)
+		}
+
+		brightSpace := color.SpaceLCH
+		if rawVariant.ANSIBrightSpace != "" {
+			brightSpace = color.Space(rawVariant.ANSIBrightSpace)
+		}
+
+		ansiOverrides := make(map[string]string, len(rawVariant.ANSIColors))
+		for slot, colorID := range rawVariant.ANSIColors {
+			if id, exists := renamed[colorID]; exists {
+				colorID = id
+			}
+			ansiOverrides[slot] = colorID
+		}
+
+		ansiMappings := getANSIMappings(candidates, variant, rawVariant.Dark, ansiOverrides)
+		bright := ansiBright(rawVariant, brightSpace)
+		for ansiIndex, ansiName := range ansiNames {
+			// Slots the variant has no color for are left out rather than
+			// guessed; ANSIWarnings reports them.
 			ansiMapping := ansiMappings[ansiName]
-			variant.AnsiPaletteColors[ansiName] = ProcessANSIColor(ansiName, ansiMapping, ansiIndex, variant, rawVariant.Dark)
+			if _, exists := variant.PaletteColors[ansiMapping.Mapping]; !exists {
+				continue
+			}
+			variant.AnsiPaletteColors[ansiName] = ProcessANSIColor(ansiName, ansiMapping, ansiIndex, variant, brightSpace, bright)
+		}
+
+		if opts.IncludeANSI256 && len(variant.AnsiPaletteColors) == len(ansiNames) {
+			variant.ANSI256 = ANSI256(variant)
 		}
 
+		if opts.IncludeOKLCH {
+			addOKLCH(&variant)
+		}
+
+		result.Naming = string(names.Naming)
 		result.Variants[rawVariant.ID] = variant
 	}
 
 	return result
 }
 
+// ansiCandidates lists, for each chromatic ANSI color, the renamed colors it
+// can be taken from. The candidates of the scheme the colors were written in
+// come first, so that converting a palette to another scheme keeps its
+// terminal colors.
+func ansiCandidates(rawColors []types.RawPaletteColor, renamed map[string]string, names NameSet) map[string][]string {
+	ids := make([]string, len(rawColors))
+	for i, rawColor := range rawColors {
+		ids[i] = rawColor.ID
+	}
+	source := DetectNameSet(ids)
+
+	candidates := make(map[string][]string, len(names.ANSI))
+	for ansiName, fallbacks := range names.ANSI {
+		for _, id := range source.ANSI[ansiName] {
+			if to, exists := renamed[id]; exists {
+				candidates[ansiName] = append(candidates[ansiName], to)
+			}
+		}
+		candidates[ansiName] = append(candidates[ansiName], fallbacks...)
+	}
+	return candidates
+}
+
+// colorOrder lists the standard colors in the order of the name set,
+// followed by the extensions in config order.
+func colorOrder(names NameSet, colors map[string]types.PaletteColor) []string {
+	var order []string
+	for _, id := range append(append([]string{}, names.Accents...), names.Semantics...) {
+		if _, exists := colors[id]; exists {
+			order = append(order, id)
+		}
+	}
+
+	var extensions []string
+	for id, paletteColor := range colors {
+		if paletteColor.Extension {
+			extensions = append(extensions, id)
+		}
+	}
+	sort.Slice(extensions, func(i, j int) bool {
+		a, b := colors[extensions[i]], colors[extensions[j]]
+		if a.Order != b.Order {
+			return a.Order < b.Order
+		}
+		return extensions[i] < extensions[j]
+	})
+	return append(order, extensions...)
+}
+
+// canonicalIDs maps each color ID to the name used by the output scheme. A
+// color keeps its ID when the aliased name is already taken in the variant.
+func canonicalIDs(rawColors []types.RawPaletteColor, names NameSet) map[string]string {
+	taken := make(map[string]bool, len(rawColors))
+	for _, rawColor := range rawColors {
+		taken[rawColor.ID] = true
+	}
+
+	renamed := make(map[string]string, len(rawColors))
+	for _, rawColor := range rawColors {
+		id := names.Canonical(rawColor.ID)
+		if id != rawColor.ID && taken[id] {
+			id = rawColor.ID
+		}
+		taken[id] = true
+		renamed[rawColor.ID] = id
+	}
+	return renamed
+}
+
+func displayName(id string) string {
+	return strings.Title(id)
+}
+
 func ProcessColor(rawColor types.RawPaletteColor, order int) types.PaletteColor {
 	newColor := color.NewColor(rawColor.Hex)
 
@@ -67,7 +229,12 @@ func ProcessColor(rawColor types.RawPaletteColor, order int) types.PaletteColor
 
 	hsl := color.TinyColorHSL(rawColor.Hex)
 
-	return types.PaletteColor{
+	if alpha := alphaValue(newColor); alpha != nil {
+		rgb.A = alpha
+		hsl.A = alpha
+	}
+
+	paletteColor := types.PaletteColor{
 		Name:   rawColor.Name,
 		Order:  order,
 		Hex:    rawColor.Hex,
@@ -75,58 +242,86 @@ func ProcessColor(rawColor types.RawPaletteColor, order int) types.PaletteColor
 		HSL:    hsl,
 		Accent: rawColor.Accent,
 	}
-}
 
-func ProcessANSIColor(ansiName string, mapping types.ANSIMapping, order int, variant types.PaletteVariant, isDark bool) types.ANSIColor {
-	var normalColor *color.Color
-	var normalName string
+	if rawColor.Source != "" {
+		source := sourceColor(rawColor)
+		paletteColor.OutOfGamut = !source.InGamut()
 
-	if ansiName == "black" {
-		if isDark {
-			normalColor = color.NewColor(findColorHex(variant, "surface1"))
-		} else {
-			normalColor = color.NewColor(findColorHex(variant, "subtext1"))
+		_, space, _ := color.ParseColorFunction(rawColor.Source)
+		switch space {
+		case color.RGBSpaceDisplayP3:
+			paletteColor.DisplayP3 = toRGBCoords(source.DisplayP3(), source)
+		case color.RGBSpaceRec2020:
+			paletteColor.Rec2020 = toRGBCoords(source.Rec2020(), source)
+		default:
+			if paletteColor.OutOfGamut {
+				paletteColor.DisplayP3 = toRGBCoords(source.DisplayP3(), source)
+			}
 		}
-		normalName = "Black"
-	} else if ansiName == "white" {
-		if isDark {
-			normalColor = color.NewColor(findColorHex(variant, "subtext0"))
-		} else {
-			normalColor = color.NewColor(findColorHex(variant, "surface2"))
+	}
+
+	return paletteColor
+}
+
+// sourceColor returns the color as written in the config, keeping precision
+// that the sRGB hex fallback cannot represent.
+func sourceColor(rawColor types.RawPaletteColor) *color.Color {
+	if rawColor.Source != "" {
+		if parsed, err := color.Parse(rawColor.Source); err == nil {
+			return parsed
 		}
-		normalName = "White"
-	} else {
-		normalColor = color.NewColor(findColorHex(variant, mapping.Mapping))
-		normalName = strings.Title(ansiName)
 	}
+	return color.NewColor(rawColor.Hex)
+}
 
-	brightColor := normalColor.Clone()
+func addWideGamut(paletteColor *types.PaletteColor, rawColor types.RawPaletteColor) {
+	source := sourceColor(rawColor)
 
-	if ansiName != "black" && ansiName != "white" {
-		lch := brightColor.GetLCH()
+	paletteColor.DisplayP3 = toRGBCoords(source.DisplayP3(), source)
+	paletteColor.Rec2020 = toRGBCoords(source.Rec2020(), source)
+}
 
-		if isDark {
-			lch.SetL(lch.L() * 0.94)
-			lch.SetC(lch.C() + 8)
-		} else {
-			lch.SetL(lch.L() * 1.09)
-			lch.SetC(lch.C() + 0)
-		}
-		lch.SetH(lch.H() + 2)
+func toRGBCoords(coords [3]float64, c *color.Color) *types.RGBCoords {
+	return &types.RGBCoords{R: coords[0], G: coords[1], B: coords[2], A: alphaValue(c)}
+}
+
+// alphaValue returns nil for opaque colors so that alpha is only written to
+// palette.json for translucent ones.
+func alphaValue(c *color.Color) *float64 {
+	if c.Alpha() >= 1 {
+		return nil
+	}
+	alpha := c.Alpha()
+	return &alpha
+}
+
+func ProcessTranslucentColor(rawTranslucent types.RawTranslucentColor, variant types.PaletteVariant, order int) types.PaletteColor {
+	from := variant.PaletteColors[rawTranslucent.From]
+
+	translucent := color.NewColor(from.Hex)
+	translucent.SetAlpha(translucent.Alpha() * rawTranslucent.Alpha)
+
+	return ProcessColor(types.RawPaletteColor{
+		ID:     rawTranslucent.ID,
+		Name:   rawTranslucent.Name,
+		Hex:    translucent.ToString(),
+		Accent: from.Accent,
+	}, order)
+}
+
+// ProcessANSIColor builds an ANSI color from the colors its mapping names.
+// When the mapping has no bright color, the bright one is derived from the
+// normal color with bright in the given working space.
+func ProcessANSIColor(ansiName string, mapping types.ANSIMapping, order int, variant types.PaletteVariant, brightSpace color.Space, bright ANSIBright) types.ANSIColor {
+	normalName := strings.Title(ansiName)
+	normalColor := color.NewColor(findColorHex(variant, mapping.Mapping))
+
+	var brightColor *color.Color
+	if _, exists := variant.PaletteColors[mapping.BrightMapping]; exists {
+		brightColor = color.NewColor(findColorHex(variant, mapping.BrightMapping))
 	} else {
-		if ansiName == "black" {
-			if isDark {
-				brightColor = color.NewColor(findColorHex(variant, "surface2"))
-			} else {
-				brightColor = color.NewColor(findColorHex(variant, "subtext0"))
-			}
-		} else {
-			if isDark {
-				brightColor = color.NewColor(findColorHex(variant, "subtext1"))
-			} else {
-				brightColor = color.NewColor(findColorHex(variant, "surface1"))
-			}
-		}
+		brightColor = normalColor.Clone()
+		brighten(brightColor, brightSpace, bright)
 	}
 
 	normalVariant := colorToANSIVariant(normalColor, normalName, mapping.NormalCode)
@@ -140,6 +335,90 @@ func ProcessANSIColor(ansiName string, mapping types.ANSIMapping, order int, var
 	}
 }
 
+// brighten derives a bright ANSI color in the given working space.
+func brighten(c *color.Color, space color.Space, bright ANSIBright) {
+	if space == color.SpaceOKLCH {
+		oklch := c.GetOKLCH()
+		oklch.SetL(oklch.L() * bright.Lightness)
+		oklch.SetC(oklch.C() + bright.Chroma)
+		oklch.SetH(oklch.H() + bright.Hue)
+		return
+	}
+
+	lch := c.GetLCH()
+	lch.SetL(lch.L() * bright.Lightness)
+	lch.SetC(lch.C() + bright.Chroma)
+	lch.SetH(lch.H() + bright.Hue)
+}
+
+func addOKLCH(variant *types.PaletteVariant) {
+	for id, pc := range variant.PaletteColors {
+		pc.OKLCH = toOKLCH(pc.Hex)
+		variant.PaletteColors[id] = pc
+	}
+
+	for id, tc := range variant.TranslucentColors {
+		tc.OKLCH = toOKLCH(tc.Hex)
+		variant.TranslucentColors[id] = tc
+	}
+
+	for id, ac := range variant.AnsiPaletteColors {
+		ac.Normal.OKLCH = toOKLCH(ac.Normal.Hex)
+		ac.Bright.OKLCH = toOKLCH(ac.Bright.Hex)
+		variant.AnsiPaletteColors[id] = ac
+	}
+
+	for i, v := range variant.ANSI256 {
+		variant.ANSI256[i].OKLCH = toOKLCH(v.Hex)
+	}
+}
+
+func toOKLCH(hex string) *types.OKLCH {
+	c := color.NewColor(hex)
+	coords := c.OKLCH()
+	return &types.OKLCH{L: coords[0], C: coords[1], H: coords[2], A: alphaValue(c)}
+}
+
+func GamutWarnings(result types.PaletteResult) []string {
+	var warnings []string
+
+	for variantID, variant := range result.Variants {
+		for colorID, paletteColor := range variant.PaletteColors {
+			if paletteColor.OutOfGamut {
+				warnings = append(warnings, fmt.Sprintf("%s: %s is outside the sRGB gamut, hex fallback is %s", variantID, colorID, paletteColor.Hex))
+			}
+		}
+
+		for _, ansiColor := range variant.AnsiPaletteColors {
+			for _, v := range []types.ANSIVariant{ansiColor.Normal, ansiColor.Bright} {
+				if v.OutOfGamut {
+					warnings = append(warnings, fmt.Sprintf("%s: %s was out of the sRGB gamut and has been mapped to %s", variantID, v.Name, v.Hex))
+				}
+			}
+		}
+	}
+
+	sort.Strings(warnings)
+	return warnings
+}
+
+// ANSIWarnings lists the ANSI colors that were left out because the variant
+// defines none of the colors they are taken from.
+func ANSIWarnings(result types.PaletteResult) []string {
+	var warnings []string
+
+	for variantID, variant := range result.Variants {
+		for _, ansiName := range ansiNames {
+			if _, exists := variant.AnsiPaletteColors[ansiName]; !exists {
+				warnings = append(warnings, fmt.Sprintf("%s: no color for ANSI %s, define one or map it under ansi.colors", variantID, ansiName))
+			}
+		}
+	}
+
+	sort.Strings(warnings)
+	return warnings
+}
+
 func getRawVariants() []types.RawVariant {
 	return []types.RawVariant{
 		{
@@ -179,49 +458,82 @@ func getRawVariants() []types.RawVariant {
 	}
 }
 
-func getANSIMappings() map[string]types.ANSIMapping {
-	return map[string]types.ANSIMapping{
+// getANSIMappings takes each chromatic ANSI color from the first of its
+// candidate accents that the variant defines, and black and white from the
+// semantic ramp. Overrides map ANSI slots to the color they should use
+// instead.
+func getANSIMappings(ansiCandidates map[string][]string, variant types.PaletteVariant, isDark bool, overrides map[string]string) map[string]types.ANSIMapping {
+	mappingFor := func(ansiName string) string {
+		candidates := ansiCandidates[ansiName]
+		for _, candidate := range candidates {
+			if _, exists := variant.PaletteColors[candidate]; exists {
+				return candidate
+			}
+		}
+		return ""
+	}
+
+	mappings := map[string]types.ANSIMapping{
 		"black": {
-			NormalCode: 0,
-			BrightCode: 8,
-			Mapping:    "",
+			NormalCode:    0,
+			BrightCode:    8,
+			Mapping:       "subtext1",
+			BrightMapping: "subtext0",
 		},
 		"red": {
 			NormalCode: 1,
 			BrightCode: 9,
-			Mapping:    "red",
+			Mapping:    mappingFor("red"),
 		},
 		"green": {
 			NormalCode: 2,
 			BrightCode: 10,
-			Mapping:    "green",
+			Mapping:    mappingFor("green"),
 		},
 		"yellow": {
 			NormalCode: 3,
 			BrightCode: 11,
-			Mapping:    "yellow",
+			Mapping:    mappingFor("yellow"),
 		},
 		"blue": {
 			NormalCode: 4,
 			BrightCode: 12,
-			Mapping:    "blue",
+			Mapping:    mappingFor("blue"),
 		},
 		"magenta": {
 			NormalCode: 5,
 			BrightCode: 13,
-			Mapping:    "pink",
+			Mapping:    mappingFor("magenta"),
 		},
 		"cyan": {
 			NormalCode: 6,
 			BrightCode: 14,
-			Mapping:    "teal",
+			Mapping:    mappingFor("cyan"),
 		},
 		"white": {
-			NormalCode: 7,
-			BrightCode: 15,
-			Mapping:    "",
+			NormalCode:    7,
+			BrightCode:    15,
+			Mapping:       "surface2",
+			BrightMapping: "surface1",
 		},
 	}
+	if isDark {
+		mappings["black"] = types.ANSIMapping{NormalCode: 0, BrightCode: 8, Mapping: "surface1", BrightMapping: "surface2"}
+		mappings["white"] = types.ANSIMapping{NormalCode: 7, BrightCode: 15, Mapping: "subtext0", BrightMapping: "subtext1"}
+	}
+
+	for _, ansiName := range ansiNames {
+		mapping := mappings[ansiName]
+		if colorID, exists := overrides[ansiName]; exists {
+			mapping.Mapping = colorID
+		}
+		if colorID, exists := overrides[brightSlot(ansiName)]; exists {
+			mapping.BrightMapping = colorID
+		}
+		mappings[ansiName] = mapping
+	}
+
+	return mappings
 }
 
 func findColorHex(variant types.PaletteVariant, colorID string) string {
@@ -242,11 +554,17 @@ func colorToANSIVariant(c *color.Color, name string, code int) types.ANSIVariant
 	hex := c.ToString()
 	hsl := color.TinyColorHSL(hex)
 
+	if alpha := alphaValue(c); alpha != nil {
+		rgb.A = alpha
+		hsl.A = alpha
+	}
+
 	return types.ANSIVariant{
-		Name: name,
-		Hex:  hex,
-		RGB:  rgb,
-		HSL:  hsl,
-		Code: code,
+		Name:       name,
+		Hex:        hex,
+		RGB:        rgb,
+		HSL:        hsl,
+		Code:       code,
+		OutOfGamut: !c.InGamut(),
 	}
 }
@@ -0,0 +1,309 @@
+package palette
+
+import (
+	"fmt"
+	"image"
+	_ "image/jpeg"
+	_ "image/png"
+	"math"
+	"math/rand"
+	"os"
+	"sort"
+
+	"github.com/openpalettestandard/openpalette/internal/color"
+)
+
+const (
+	DefaultClusters   = 16
+	DefaultMaxSamples = 20000
+
+	// minAccentChroma separates colorful clusters, which become accent seeds,
+	// from near-neutral ones, which are base and text candidates.
+	minAccentChroma = 0.04
+
+	// minSeedDistance is the OKLab distance below which two clusters are
+	// treated as the same accent.
+	minSeedDistance = 0.08
+
+	maxImageSeeds = 6
+)
+
+type ImageOptions struct {
+	Clusters   int
+	MaxSamples int
+	Seed       int64
+
+	// Dark forces the polarity of the variant. When nil it follows the
+	// average lightness of the image.
+	Dark *bool
+
+	ID     string
+	Name   string
+	Naming string
+}
+
+type cluster struct {
+	lab    [3]float64
+	weight int
+}
+
+func ExtractFromFile(filename string, opts ImageOptions) (ConfigFile, error) {
+	file, err := os.Open(filename)
+	if err != nil {
+		return ConfigFile{}, fmt.Errorf("opening image: %w", err)
+	}
+	defer file.Close()
+
+	img, _, err := image.Decode(file)
+	if err != nil {
+		return ConfigFile{}, fmt.Errorf("decoding image: %w", err)
+	}
+
+	return ExtractFromImage(img, opts)
+}
+
+// ExtractFromImage clusters the pixels of img in OKLab with k-means and turns
+// the result into a config variant: the most frequent colorful clusters are
+// used as seeds for the accents, and base and text are taken from the
+// near-neutral clusters closest to the lightness ranges of each, as
+// imageNeutrals describes. The result only depends on the image and
+// opts.Seed.
+func ExtractFromImage(img image.Image, opts ImageOptions) (ConfigFile, error) {
+	if opts.Clusters <= 0 {
+		opts.Clusters = DefaultClusters
+	}
+	if opts.MaxSamples <= 0 {
+		opts.MaxSamples = DefaultMaxSamples
+	}
+
+	samples := samplePixels(img, opts.MaxSamples)
+	if len(samples) == 0 {
+		return ConfigFile{}, fmt.Errorf("image has no opaque pixels")
+	}
+
+	clusters := kMeans(samples, opts.Clusters, rand.New(rand.NewSource(opts.Seed)))
+
+	dark := meanLightness(clusters) < 0.5
+	if opts.Dark != nil {
+		dark = *opts.Dark
+	}
+
+	seeds := imageSeeds(clusters)
+	if len(seeds) == 0 {
+		return ConfigFile{}, fmt.Errorf("image has no colorful areas to take accents from")
+	}
+	base, text := imageNeutrals(clusters, dark)
+
+	return GenerateFromSeeds(SeedOptions{
+		Seeds:  seeds,
+		Base:   base.ToString(),
+		Text:   text.ToString(),
+		Dark:   dark,
+		ID:     opts.ID,
+		Name:   opts.Name,
+		Naming: opts.Naming,
+	})
+}
+
+// samplePixels reads at most maxSamples pixels on an even grid and skips
+// mostly transparent ones.
+func samplePixels(img image.Image, maxSamples int) [][3]float64 {
+	bounds := img.Bounds()
+	step := int(math.Ceil(math.Sqrt(float64(bounds.Dx()*bounds.Dy()) / float64(maxSamples))))
+	if step < 1 {
+		step = 1
+	}
+
+	var samples [][3]float64
+	for y := bounds.Min.Y; y < bounds.Max.Y; y += step {
+		for x := bounds.Min.X; x < bounds.Max.X; x += step {
+			r, g, b, a := img.At(x, y).RGBA()
+			if a < 0x8000 {
+				continue
+			}
+
+			// Colors are premultiplied, so undo alpha before converting.
+			hex := fmt.Sprintf("%02x%02x%02x", r*0xff/a, g*0xff/a, b*0xff/a)
+			samples = append(samples, color.NewColor(hex).OKLab())
+		}
+	}
+	return samples
+}
+
+// kMeans clusters samples with k-means++ seeding, drawing all randomness from
+// rng, and returns the non-empty clusters.
+func kMeans(samples [][3]float64, k int, rng *rand.Rand) []cluster {
+	if k > len(samples) {
+		k = len(samples)
+	}
+
+	centroids := [][3]float64{samples[rng.Intn(len(samples))]}
+	distances := make([]float64, len(samples))
+	for len(centroids) < k {
+		total := 0.0
+		for i, sample := range samples {
+			distances[i] = math.Inf(1)
+			for _, centroid := range centroids {
+				distances[i] = math.Min(distances[i], squaredDistance(sample, centroid))
+			}
+			total += distances[i]
+		}
+		if total == 0 {
+			break
+		}
+
+		target := rng.Float64() * total
+		next := len(samples) - 1
+		for i, distance := range distances {
+			target -= distance
+			if target <= 0 {
+				next = i
+				break
+			}
+		}
+		centroids = append(centroids, samples[next])
+	}
+
+	assignment := make([]int, len(samples))
+	for iteration := 0; iteration < 50; iteration++ {
+		changed := false
+		for i, sample := range samples {
+			nearest, nearestDistance := 0, math.Inf(1)
+			for j, centroid := range centroids {
+				if distance := squaredDistance(sample, centroid); distance < nearestDistance {
+					nearest, nearestDistance = j, distance
+				}
+			}
+			if assignment[i] != nearest || iteration == 0 {
+				assignment[i] = nearest
+				changed = true
+			}
+		}
+		if !changed {
+			break
+		}
+
+		sums := make([][3]float64, len(centroids))
+		counts := make([]int, len(centroids))
+		for i, sample := range samples {
+			j := assignment[i]
+			for c := 0; c < 3; c++ {
+				sums[j][c] += sample[c]
+			}
+			counts[j]++
+		}
+		for j := range centroids {
+			if counts[j] == 0 {
+				continue
+			}
+			for c := 0; c < 3; c++ {
+				centroids[j][c] = sums[j][c] / float64(counts[j])
+			}
+		}
+	}
+
+	counts := make([]int, len(centroids))
+	for _, j := range assignment {
+		counts[j]++
+	}
+
+	var clusters []cluster
+	for j, centroid := range centroids {
+		if counts[j] > 0 {
+			clusters = append(clusters, cluster{lab: centroid, weight: counts[j]})
+		}
+	}
+	sort.SliceStable(clusters, func(i, j int) bool {
+		return clusters[i].weight > clusters[j].weight
+	})
+	return clusters
+}
+
+func squaredDistance(a, b [3]float64) float64 {
+	dl, da, db := a[0]-b[0], a[1]-b[1], a[2]-b[2]
+	return dl*dl + da*da + db*db
+}
+
+func (c cluster) chroma() float64 {
+	return math.Hypot(c.lab[1], c.lab[2])
+}
+
+func (c cluster) color() *color.Color {
+	return color.NewOKLab(c.lab[0], c.lab[1], c.lab[2])
+}
+
+func meanLightness(clusters []cluster) float64 {
+	sum, weight := 0.0, 0
+	for _, c := range clusters {
+		sum += c.lab[0] * float64(c.weight)
+		weight += c.weight
+	}
+	return sum / float64(weight)
+}
+
+// imageSeeds returns the most frequent colorful clusters, skipping any that
+// are too close to one already chosen.
+func imageSeeds(clusters []cluster) []string {
+	var chosen []cluster
+	for _, c := range clusters {
+		if c.chroma() < minAccentChroma {
+			continue
+		}
+
+		distinct := true
+		for _, other := range chosen {
+			if math.Sqrt(squaredDistance(c.lab, other.lab)) < minSeedDistance {
+				distinct = false
+				break
+			}
+		}
+		if distinct {
+			chosen = append(chosen, c)
+		}
+		if len(chosen) == maxImageSeeds {
+			break
+		}
+	}
+
+	seeds := make([]string, len(chosen))
+	for i, c := range chosen {
+		seeds[i] = c.color().ToString()
+	}
+	return seeds
+}
+
+// imageNeutrals picks base from the near-neutral cluster closest to the
+// background end of the lightness range and text from the one closest to the
+// other end, considering every cluster only when the image has no
+// near-neutral ones. Both are pulled into the lightness range of the
+// Catppuccin flavors and their chroma is capped, so that any image yields a
+// usable ramp.
+func imageNeutrals(clusters []cluster, dark bool) (*color.Color, *color.Color) {
+	baseRange, textRange := neutralRanges(dark)
+
+	var neutrals []cluster
+	for _, c := range clusters {
+		if c.chroma() < minAccentChroma {
+			neutrals = append(neutrals, c)
+		}
+	}
+	if len(neutrals) == 0 {
+		neutrals = clusters
+	}
+
+	pick := func(lightRange [2]float64, maxChroma float64) *color.Color {
+		target := (lightRange[0] + lightRange[1]) / 2
+		best := neutrals[0]
+		for _, c := range neutrals[1:] {
+			if math.Abs(c.lab[0]-target) < math.Abs(best.lab[0]-target) {
+				best = c
+			}
+		}
+
+		coords := best.color().OKLCH()
+		l := clampFloat(coords[0], lightRange[0], lightRange[1])
+		return color.NewOKLCH(l, math.Min(coords[1], maxChroma), coords[2])
+	}
+
+	return pick(baseRange, 0.03), pick(textRange, 0.04)
+}
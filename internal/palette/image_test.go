@@ -0,0 +1,92 @@
+package palette
+
+import (
+	"encoding/json"
+	"image"
+	imagecolor "image/color"
+	"math"
+	"testing"
+
+	"github.com/openpalettestandard/openpalette/internal/color"
+)
+
+// testImage is mostly a dark background with a red and a blue block.
+func testImage() image.Image {
+	img := image.NewRGBA(image.Rect(0, 0, 60, 60))
+	for y := 0; y < 60; y++ {
+		for x := 0; x < 60; x++ {
+			fill := imagecolor.RGBA{0x1e, 0x1e, 0x2e, 0xff}
+			switch {
+			case y < 15 && x < 30:
+				fill = imagecolor.RGBA{0xe0, 0x40, 0x50, 0xff}
+			case y < 15:
+				fill = imagecolor.RGBA{0x40, 0x70, 0xe0, 0xff}
+			case y > 55:
+				fill = imagecolor.RGBA{0xdd, 0xdd, 0xe8, 0xff}
+			}
+			img.Set(x, y, fill)
+		}
+	}
+	return img
+}
+
+func TestExtractFromImage(t *testing.T) {
+	var first []byte
+	for i := 0; i < 3; i++ {
+		config, err := ExtractFromImage(testImage(), ImageOptions{Clusters: 6, Seed: 42})
+		if err != nil {
+			t.Fatalf("extracting from image: %v", err)
+		}
+
+		data, err := json.Marshal(config)
+		if err != nil {
+			t.Fatalf("marshaling config: %v", err)
+		}
+		if first == nil {
+			first = data
+		} else if string(first) != string(data) {
+			t.Fatalf("run %d produced a different config", i)
+		}
+	}
+
+	var config ConfigFile
+	if err := json.Unmarshal(first, &config); err != nil {
+		t.Fatalf("reading config back: %v", err)
+	}
+
+	variant, exists := config.Variants["dark"]
+	if !exists || !variant.Dark {
+		t.Fatalf("expected a dark variant, got %+v", config.Variants)
+	}
+
+	accents := 0
+	for _, c := range variant.Colors {
+		if c.Accent {
+			accents++
+		}
+	}
+	if accents != 14 {
+		t.Errorf("expected 14 accents, got %d", accents)
+	}
+
+	redHue := color.NewColor("#e04050").OKLCH()[2]
+	if hue := color.NewColor(variant.Colors["red"].Hex).OKLCH()[2]; math.Abs(hue-redHue) > 2 {
+		t.Errorf("expected red to take the hue %.1f of the red block, got %.1f", redHue, hue)
+	}
+	if l := color.NewColor(variant.Colors["base"].Hex).OKLCH()[0]; l > 0.3 {
+		t.Errorf("expected a dark base, got lightness %.2f", l)
+	}
+}
+
+func TestImageNeutralsPreferLowChroma(t *testing.T) {
+	clusters := []cluster{
+		{lab: [3]float64{0.2, 0.002, -0.004}, weight: 50},
+		{lab: [3]float64{0.89, 0.02, 0.18}, weight: 30},
+		{lab: [3]float64{0.8, 0.003, 0.003}, weight: 20},
+	}
+
+	_, text := imageNeutrals(clusters, true)
+	if chroma := text.OKLCH()[1]; chroma > 0.01 {
+		t.Errorf("expected text from the gray cluster rather than the yellow one, got chroma %.3f", chroma)
+	}
+}
@@ -0,0 +1,150 @@
+package palette
+
+import (
+	"fmt"
+	"strings"
+)
+
+type Naming string
+
+const (
+	NamingCatppuccin  Naming = "catppuccin"
+	NamingOpenPalette Naming = "openpalette"
+
+	DefaultNaming = NamingCatppuccin
+)
+
+// NameSet is one accepted naming scheme for the 14 accents and 12 semantic
+// elements, listed in output order.
+type NameSet struct {
+	Naming    Naming
+	Accents   []string
+	Semantics []string
+
+	// ANSI lists, for each chromatic ANSI color, the accents it is taken from
+	// in order of preference.
+	ANSI map[string][]string
+}
+
+var semanticNames = []string{
+	"text", "subtext1", "subtext0", "overlay2", "overlay1", "overlay0",
+	"surface2", "surface1", "surface0", "base", "mantle", "crust",
+}
+
+var NameSets = []NameSet{
+	{
+		Naming: NamingCatppuccin,
+		Accents: []string{
+			"rosewater", "flamingo", "pink", "mauve", "red", "maroon", "peach",
+			"yellow", "green", "teal", "sky", "sapphire", "blue", "lavender",
+		},
+		Semantics: semanticNames,
+		ANSI: map[string][]string{
+			"red":     {"red"},
+			"green":   {"green"},
+			"yellow":  {"yellow"},
+			"blue":    {"blue"},
+			"magenta": {"pink"},
+			"cyan":    {"teal"},
+		},
+	},
+	{
+		Naming: NamingOpenPalette,
+		Accents: []string{
+			"red", "maroon", "pink", "orange", "yellow", "green", "teal",
+			"cyan", "sky", "blue", "sapphire", "purple", "mauve", "lavender",
+		},
+		Semantics: semanticNames,
+		ANSI: map[string][]string{
+			"red":     {"red"},
+			"green":   {"green"},
+			"yellow":  {"yellow"},
+			"blue":    {"blue"},
+			"magenta": {"pink", "purple"},
+			"cyan":    {"cyan", "teal"},
+		},
+	},
+}
+
+// aliases pairs names that refer to the same color in different schemes.
+var aliases = [][2]string{
+	{"orange", "peach"},
+}
+
+func LookupNameSet(naming string) (NameSet, error) {
+	if naming == "" {
+		naming = string(DefaultNaming)
+	}
+
+	var known []string
+	for _, names := range NameSets {
+		if string(names.Naming) == naming {
+			return names, nil
+		}
+		known = append(known, string(names.Naming))
+	}
+	return NameSet{}, fmt.Errorf("unknown naming scheme %q (expected %s)", naming, strings.Join(known, " or "))
+}
+
+func defaultNameSet() NameSet {
+	names, _ := LookupNameSet("")
+	return names
+}
+
+// DetectNameSet picks the scheme that recognizes most of the given color IDs,
+// preferring the default scheme on a tie.
+func DetectNameSet(ids []string) NameSet {
+	best, bestCount := defaultNameSet(), -1
+	for _, names := range NameSets {
+		count := 0
+		for _, id := range ids {
+			if names.IsStandard(id) {
+				count++
+			}
+		}
+		if count > bestCount || (count == bestCount && names.Naming == DefaultNaming) {
+			best, bestCount = names, count
+		}
+	}
+	return best
+}
+
+func (ns NameSet) Names() []string {
+	return append(append([]string{}, ns.Accents...), ns.Semantics...)
+}
+
+func (ns NameSet) IsStandard(id string) bool {
+	for _, name := range ns.Names() {
+		if name == id {
+			return true
+		}
+	}
+	return false
+}
+
+// Canonical returns the name this scheme uses for id, following aliases.
+// Names the scheme does not know are returned unchanged.
+func (ns NameSet) Canonical(id string) string {
+	if ns.IsStandard(id) {
+		return id
+	}
+	for _, alias := range Aliases(id) {
+		if ns.IsStandard(alias) {
+			return alias
+		}
+	}
+	return id
+}
+
+func Aliases(id string) []string {
+	var result []string
+	for _, pair := range aliases {
+		switch id {
+		case pair[0]:
+			result = append(result, pair[1])
+		case pair[1]:
+			result = append(result, pair[0])
+		}
+	}
+	return result
+}
@@ -0,0 +1,50 @@
+package palette
+
+import "testing"
+
+func TestCanonical(t *testing.T) {
+	catppuccin, _ := LookupNameSet(string(NamingCatppuccin))
+	openpalette, _ := LookupNameSet(string(NamingOpenPalette))
+
+	tests := []struct {
+		names NameSet
+		id    string
+		want  string
+	}{
+		{catppuccin, "orange", "peach"},
+		{catppuccin, "peach", "peach"},
+		{openpalette, "peach", "orange"},
+		{openpalette, "rosewater", "rosewater"},
+		{openpalette, "brand", "brand"},
+	}
+
+	for _, tt := range tests {
+		if got := tt.names.Canonical(tt.id); got != tt.want {
+			t.Errorf("%s: Canonical(%q) = %q, want %q", tt.names.Naming, tt.id, got, tt.want)
+		}
+	}
+
+	if _, err := LookupNameSet("material"); err == nil {
+		t.Error("expected an error for an unknown naming scheme")
+	}
+}
+
+func TestGenerateWithNaming(t *testing.T) {
+	result := Generate(Options{Naming: string(NamingOpenPalette)})
+	latte := result.Variants["latte"]
+
+	orange, exists := latte.PaletteColors["orange"]
+	if !exists || orange.Name != "Orange" || orange.Extension {
+		t.Errorf("expected peach to be renamed to a standard orange, got %+v (exists %t)", orange, exists)
+	}
+	if _, exists := latte.PaletteColors["peach"]; exists {
+		t.Error("expected peach to be replaced by orange")
+	}
+	if !latte.PaletteColors["rosewater"].Extension {
+		t.Error("expected rosewater to be an extension in the openpalette scheme")
+	}
+
+	if got, want := latte.AnsiPaletteColors["cyan"].Normal.Hex, latte.PaletteColors["teal"].Hex; got != want {
+		t.Errorf("expected ANSI cyan to fall back to teal %s, got %s", want, got)
+	}
+}
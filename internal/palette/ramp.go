@@ -0,0 +1,101 @@
+package palette
+
+import (
+	"github.com/openpalettestandard/openpalette/internal/color"
+	"github.com/openpalettestandard/openpalette/internal/types"
+)
+
+// rampPositions places each semantic element on the line from base (0) to
+// text (1). The values follow the lightness steps of the Catppuccin flavors;
+// mantle and crust are darker than base, so they continue past base, away
+// from text, in dark variants and move towards text in light ones.
+var rampPositions = map[string]float64{
+	"text":     1,
+	"subtext1": 0.9,
+	"subtext0": 0.79,
+	"overlay2": 0.69,
+	"overlay1": 0.59,
+	"overlay0": 0.48,
+	"surface2": 0.37,
+	"surface1": 0.27,
+	"surface0": 0.16,
+	"base":     0,
+	"mantle":   -0.045,
+	"crust":    -0.095,
+}
+
+// neutralRanges returns the OKLCH lightness ranges that base and text fall
+// in across the Catppuccin flavors of the given polarity.
+func neutralRanges(dark bool) (base, text [2]float64) {
+	if dark {
+		return [2]float64{0.15, 0.3}, [2]float64{0.82, 0.95}
+	}
+	return [2]float64{0.9, 0.98}, [2]float64{0.3, 0.5}
+}
+
+var rampNames = map[string]string{
+	"text":     "Text",
+	"subtext1": "Subtext 1",
+	"subtext0": "Subtext 0",
+	"overlay2": "Overlay 2",
+	"overlay1": "Overlay 1",
+	"overlay0": "Overlay 0",
+	"surface2": "Surface 2",
+	"surface1": "Surface 1",
+	"surface0": "Surface 0",
+	"base":     "Base",
+	"mantle":   "Mantle",
+	"crust":    "Crust",
+}
+
+// FillSemanticRamp interpolates any semantic elements missing from rawColors
+// between base and text. Provided colors are kept as they are, and the whole
+// semantic block is placed, in ramp order, where the first semantic color was.
+// dark picks the direction of mantle and crust.
+func FillSemanticRamp(rawColors []types.RawPaletteColor, dark bool, space color.Space) []types.RawPaletteColor {
+	provided := make(map[string]types.RawPaletteColor)
+	for _, rawColor := range rawColors {
+		if _, semantic := rampPositions[rawColor.ID]; semantic {
+			provided[rawColor.ID] = rawColor
+		}
+	}
+
+	base, hasBase := provided["base"]
+	text, hasText := provided["text"]
+	if !hasBase || !hasText || len(provided) == len(rampPositions) {
+		return rawColors
+	}
+
+	baseColor, textColor := sourceColor(base), sourceColor(text)
+
+	ramp := make([]types.RawPaletteColor, 0, len(semanticNames))
+	for _, id := range semanticNames {
+		if rawColor, exists := provided[id]; exists {
+			ramp = append(ramp, rawColor)
+			continue
+		}
+
+		position := rampPositions[id]
+		if !dark && position < 0 {
+			position = -position
+		}
+
+		mixed := color.Mix(baseColor, textColor, position, space)
+		ramp = append(ramp, types.RawPaletteColor{
+			ID:   id,
+			Name: rampNames[id],
+			Hex:  mixed.ToString(),
+		})
+	}
+
+	filled := make([]types.RawPaletteColor, 0, len(rawColors)+len(ramp))
+	for _, rawColor := range rawColors {
+		if _, semantic := rampPositions[rawColor.ID]; !semantic {
+			filled = append(filled, rawColor)
+		} else if ramp != nil {
+			filled = append(filled, ramp...)
+			ramp = nil
+		}
+	}
+	return filled
+}
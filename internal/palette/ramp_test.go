@@ -0,0 +1,85 @@
+package palette
+
+import (
+	"testing"
+
+	"github.com/openpalettestandard/openpalette/internal/color"
+	"github.com/openpalettestandard/openpalette/internal/types"
+)
+
+func TestFillSemanticRamp(t *testing.T) {
+	mocha := map[string]string{
+		"text": "#cdd6f4", "subtext1": "#bac2de", "subtext0": "#a6adc8",
+		"overlay2": "#9399b2", "overlay1": "#7f849c", "overlay0": "#6c7086",
+		"surface2": "#585b70", "surface1": "#45475a", "surface0": "#313244",
+		"base": "#1e1e2e", "mantle": "#181825", "crust": "#11111b",
+	}
+
+	for _, space := range []color.Space{color.SpaceOKLCH, color.SpaceLCH} {
+		filled := FillSemanticRamp([]types.RawPaletteColor{
+			{ID: "red", Name: "Red", Hex: "#f38ba8", Accent: true},
+			{ID: "text", Name: "Text", Hex: mocha["text"]},
+			{ID: "base", Name: "Base", Hex: mocha["base"]},
+			{ID: "surface1", Name: "Surface 1", Hex: "#123456"},
+		}, true, space)
+
+		if len(filled) != 13 || filled[0].ID != "red" {
+			t.Fatalf("%s: expected red followed by 12 semantic elements, got %+v", space, filled)
+		}
+
+		for i, rawColor := range filled[1:] {
+			if rawColor.ID != semanticNames[i] {
+				t.Errorf("%s: position %d: expected %s, got %s", space, i+1, semanticNames[i], rawColor.ID)
+			}
+
+			if rawColor.ID == "surface1" {
+				if rawColor.Hex != "#123456" {
+					t.Errorf("%s: expected provided surface1 to be kept, got %s", space, rawColor.Hex)
+				}
+				continue
+			}
+
+			distance := color.DeltaE2000(color.NewColor(rawColor.Hex), color.NewColor(mocha[rawColor.ID]))
+			if distance > 3.5 {
+				t.Errorf("%s: %s = %s is %.2f from Catppuccin %s", space, rawColor.ID, rawColor.Hex, distance, mocha[rawColor.ID])
+			}
+		}
+	}
+}
+
+func TestFillSemanticRampLight(t *testing.T) {
+	filled := FillSemanticRamp([]types.RawPaletteColor{
+		{ID: "text", Name: "Text", Hex: "#4c4f69"},
+		{ID: "base", Name: "Base", Hex: "#eff1f5"},
+	}, false, color.SpaceOKLCH)
+
+	lightness := make(map[string]float64)
+	for _, rawColor := range filled {
+		lightness[rawColor.ID] = color.NewColor(rawColor.Hex).OKLCH()[0]
+	}
+
+	for i := 1; i < len(semanticNames)-3; i++ {
+		if lightness[semanticNames[i]] <= lightness[semanticNames[i-1]] {
+			t.Errorf("expected %s to be lighter than %s", semanticNames[i], semanticNames[i-1])
+		}
+	}
+	// As in Latte, mantle and crust are darker than base.
+	if lightness["mantle"] >= lightness["base"] || lightness["crust"] >= lightness["mantle"] {
+		t.Errorf("expected mantle and crust to be darker than base, got %v", lightness)
+	}
+}
+
+func TestFillSemanticRampWhiteBase(t *testing.T) {
+	filled := FillSemanticRamp([]types.RawPaletteColor{
+		{ID: "text", Name: "Text", Hex: "#000000"},
+		{ID: "base", Name: "Base", Hex: "#ffffff"},
+	}, false, color.SpaceOKLCH)
+
+	hex := make(map[string]string)
+	for _, rawColor := range filled {
+		hex[rawColor.ID] = rawColor.Hex
+	}
+	if hex["mantle"] == hex["base"] || hex["crust"] == hex["mantle"] {
+		t.Errorf("expected distinct base, mantle and crust, got %s, %s and %s", hex["base"], hex["mantle"], hex["crust"])
+	}
+}
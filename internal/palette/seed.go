@@ -0,0 +1,229 @@
+package palette
+
+import (
+	"fmt"
+	"math"
+	"strings"
+
+	"github.com/openpalettestandard/openpalette/internal/color"
+)
+
+// accentRole places an accent relative to the shared accent lightness and
+// chroma: Hue is its OKLCH hue, Lightness is added to the shared lightness
+// and Chroma scales the shared chroma. The offsets follow Catppuccin Mocha,
+// which separates the warm tints (rosewater, flamingo, maroon) from red by
+// lightness and chroma rather than by hue alone.
+type accentRole struct {
+	Hue       float64
+	Lightness float64
+	Chroma    float64
+
+	// Primary roles are the ones seed colors snap to first.
+	Primary bool
+}
+
+var accentRoles = map[string]accentRole{
+	"red":       {Hue: 10, Lightness: -0.08, Chroma: 1.5, Primary: true},
+	"maroon":    {Hue: 18, Lightness: -0.05, Chroma: 1.0},
+	"flamingo":  {Hue: 25, Lightness: 0.05, Chroma: 0.5},
+	"rosewater": {Hue: 35, Lightness: 0.09, Chroma: 0.3},
+	"peach":     {Hue: 50, Lightness: -0.01, Chroma: 1.2, Primary: true},
+	"orange":    {Hue: 50, Lightness: -0.01, Chroma: 1.2, Primary: true},
+	"yellow":    {Hue: 85, Lightness: 0.085, Chroma: 0.8, Primary: true},
+	"green":     {Hue: 142, Lightness: 0.02, Chroma: 1.25, Primary: true},
+	"teal":      {Hue: 185, Lightness: 0.02, Chroma: 0.9, Primary: true},
+	"cyan":      {Hue: 200, Lightness: 0.01, Chroma: 0.95, Primary: true},
+	"sky":       {Hue: 215, Lightness: 0.01, Chroma: 0.95},
+	"sapphire":  {Hue: 228, Lightness: -0.04, Chroma: 1.1},
+	"blue":      {Hue: 260, Lightness: -0.07, Chroma: 1.3, Primary: true},
+	"lavender":  {Hue: 277, Lightness: -0.02, Chroma: 1.05},
+	"purple":    {Hue: 290, Lightness: -0.05, Chroma: 1.35, Primary: true},
+	"mauve":     {Hue: 310, Lightness: -0.05, Chroma: 1.35, Primary: true},
+	"pink":      {Hue: 340, Lightness: 0.04, Chroma: 0.85, Primary: true},
+}
+
+// maxSeedRotation limits how far seeds may turn the hues of the other roles,
+// so that every accent stays recognizable as its role.
+const maxSeedRotation = 15.0
+
+type SeedOptions struct {
+	Seeds []string
+
+	// Base and Text default to neutrals tinted with the first seed's hue.
+	Base string
+	Text string
+
+	Dark   bool
+	ID     string
+	Name   string
+	Naming string
+}
+
+// GenerateFromSeeds builds a config with one variant whose 14 accents are
+// spread over the accent roles of the naming scheme. Each seed keeps its own
+// hue in the role nearest to it, the other roles are turned by the average
+// seed offset, and every accent shares the seeds' lightness and chroma before
+// being tuned for contrast against base. Only base and text are written for
+// the semantic elements; the generator interpolates the rest.
+func GenerateFromSeeds(opts SeedOptions) (ConfigFile, error) {
+	if len(opts.Seeds) == 0 {
+		return ConfigFile{}, fmt.Errorf("at least one seed color is required")
+	}
+
+	names, err := LookupNameSet(opts.Naming)
+	if err != nil {
+		return ConfigFile{}, err
+	}
+
+	seeds := make([][3]float64, len(opts.Seeds))
+	for i, seed := range opts.Seeds {
+		parsed, err := color.Parse(seed)
+		if err != nil {
+			return ConfigFile{}, fmt.Errorf("seed %d: %w", i+1, err)
+		}
+		seeds[i] = parsed.OKLCH()
+	}
+
+	assigned, err := assignSeeds(seeds, names.Accents)
+	if err != nil {
+		return ConfigFile{}, err
+	}
+
+	var lightness, chroma, sinSum, cosSum float64
+	for _, role := range names.Accents {
+		seedIndex, isSeed := assigned[role]
+		if !isSeed {
+			continue
+		}
+
+		seed, accent := seeds[seedIndex], accentRoles[role]
+		lightness += seed[0] - accent.Lightness
+		chroma += seed[1] / accent.Chroma
+
+		offset := (seed[2] - accent.Hue) * math.Pi / 180
+		sinSum += math.Sin(offset)
+		cosSum += math.Cos(offset)
+	}
+	lightness /= float64(len(assigned))
+	chroma /= float64(len(assigned))
+	rotation := clampFloat(math.Atan2(sinSum, cosSum)*180/math.Pi, -maxSeedRotation, maxSeedRotation)
+
+	base, text, err := seedNeutrals(opts, seeds[0][2])
+	if err != nil {
+		return ConfigFile{}, err
+	}
+
+	id := opts.ID
+	if id == "" {
+		id = "light"
+		if opts.Dark {
+			id = "dark"
+		}
+	}
+	name := opts.Name
+	if name == "" {
+		name = strings.Title(id)
+	}
+
+	variant := ConfigVariant{
+		Name:   name,
+		Dark:   opts.Dark,
+		Colors: make(map[string]ConfigColor, len(names.Accents)+2),
+	}
+
+	for i, role := range names.Accents {
+		accent := accentRoles[role]
+		hue := math.Mod(accent.Hue+rotation+360, 360)
+		if seedIndex, isSeed := assigned[role]; isSeed {
+			hue = seeds[seedIndex][2]
+		}
+
+		l := clampFloat(lightness+accent.Lightness, 0, 1)
+		c := color.NewOKLCH(l, inGamutChroma(l, math.Max(0, chroma*accent.Chroma), hue), hue)
+		c = tuneContrast(c, base, !opts.Dark)
+
+		order := i
+		variant.Colors[role] = ConfigColor{
+			Name:   displayName(role),
+			Hex:    c.ToString(),
+			Order:  &order,
+			Accent: true,
+		}
+	}
+
+	for i, semantic := range []struct {
+		id string
+		c  *color.Color
+	}{{"text", text}, {"base", base}} {
+		order := len(names.Accents) + i
+		variant.Colors[semantic.id] = ConfigColor{
+			Name:  displayName(semantic.id),
+			Hex:   semantic.c.ToString(),
+			Order: &order,
+		}
+	}
+
+	return ConfigFile{
+		Version:  "1.0.0",
+		Naming:   string(names.Naming),
+		Variants: map[string]ConfigVariant{id: variant},
+	}, nil
+}
+
+// primaryBonus is how many degrees closer a primary role counts as when
+// assigning seeds.
+const primaryBonus = 15.0
+
+// assignSeeds maps each seed to the free accent role closest in hue,
+// preferring primary roles so that a red seed becomes red rather than one of
+// the warm tints next to it.
+func assignSeeds(seeds [][3]float64, roles []string) (map[string]int, error) {
+	if len(seeds) > len(roles) {
+		return nil, fmt.Errorf("%d seeds given, but there are only %d accents", len(seeds), len(roles))
+	}
+
+	assigned := make(map[string]int, len(seeds))
+	for i, seed := range seeds {
+		best, bestDistance := "", math.Inf(1)
+		for _, role := range roles {
+			if _, taken := assigned[role]; taken {
+				continue
+			}
+
+			accent := accentRoles[role]
+			distance := math.Abs(math.Mod(seed[2]-accent.Hue+540, 360) - 180)
+			if accent.Primary {
+				distance -= primaryBonus
+			}
+			if distance < bestDistance {
+				best, bestDistance = role, distance
+			}
+		}
+		assigned[best] = i
+	}
+	return assigned, nil
+}
+
+func seedNeutrals(opts SeedOptions, hue float64) (*color.Color, *color.Color, error) {
+	base, text := color.NewOKLCH(0.96, 0.006, hue), color.NewOKLCH(0.44, 0.04, hue)
+	if opts.Dark {
+		base, text = color.NewOKLCH(0.24, 0.03, hue), color.NewOKLCH(0.88, 0.04, hue)
+	}
+
+	if opts.Base != "" {
+		parsed, err := color.Parse(opts.Base)
+		if err != nil {
+			return nil, nil, fmt.Errorf("base: %w", err)
+		}
+		base = parsed
+	}
+	if opts.Text != "" {
+		parsed, err := color.Parse(opts.Text)
+		if err != nil {
+			return nil, nil, fmt.Errorf("text: %w", err)
+		}
+		text = parsed
+	}
+
+	return color.NewColor(base.ToString()), color.NewColor(text.ToString()), nil
+}
@@ -0,0 +1,64 @@
+package palette
+
+import (
+	"math"
+	"testing"
+
+	"github.com/openpalettestandard/openpalette/internal/color"
+)
+
+func TestGenerateFromSeeds(t *testing.T) {
+	config, err := GenerateFromSeeds(SeedOptions{Seeds: []string{"#e86671", "oklch(0.75 0.15 150)"}, Dark: true})
+	if err != nil {
+		t.Fatalf("generating from seeds: %v", err)
+	}
+
+	variant, exists := config.Variants["dark"]
+	if !exists || !variant.Dark {
+		t.Fatalf("expected a dark variant, got %+v", config.Variants)
+	}
+
+	seedHue := color.NewColor("#e86671").OKLCH()[2]
+	if hue := color.NewColor(variant.Colors["red"].Hex).OKLCH()[2]; math.Abs(hue-seedHue) > 1 {
+		t.Errorf("expected red to keep the seed hue %.1f, got %.1f", seedHue, hue)
+	}
+	if hue := color.NewColor(variant.Colors["green"].Hex).OKLCH()[2]; math.Abs(hue-150) > 1 {
+		t.Errorf("expected green to keep the seed hue 150, got %.1f", hue)
+	}
+
+	base := color.NewColor(variant.Colors["base"].Hex)
+	accents := 0
+	for id, c := range variant.Colors {
+		if !c.Accent {
+			continue
+		}
+		accents++
+
+		if ratio := color.ContrastRatio(color.NewColor(c.Hex), base); ratio < deriveContrast {
+			t.Errorf("%s: contrast %.2f against base is below %.1f", id, ratio, deriveContrast)
+		}
+	}
+	if accents != 14 {
+		t.Errorf("expected 14 accents, got %d", accents)
+	}
+
+	rawVariants, err := convertConfigToRawVariants(config)
+	if err != nil {
+		t.Fatalf("converting seeded config: %v", err)
+	}
+	result := GenerateFromVariants(rawVariants, config.Version, Options{})
+	if colors := len(result.Variants["dark"].PaletteColors); colors != 26 {
+		t.Errorf("expected 26 colors after generating, got %d", colors)
+	}
+}
+
+func TestGenerateFromSingleSeed(t *testing.T) {
+	config, err := GenerateFromSeeds(SeedOptions{Seeds: []string{"#e86671"}, Dark: true})
+	if err != nil {
+		t.Fatalf("generating from seed: %v", err)
+	}
+
+	if got := config.Variants["dark"].Colors["red"].Hex; got != "#e86671" {
+		t.Errorf("expected a single red seed to be kept as is, got %s", got)
+	}
+}
@@ -1,11 +1,15 @@
 package types
 
 import (
+	"bytes"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"os"
 	"path/filepath"
+	"reflect"
+	"sort"
 	"strings"
 )
 
@@ -13,20 +17,32 @@ func (pr PaletteResult) MarshalJSON() ([]byte, error) {
 	var buf strings.Builder
 	buf.WriteString("{")
 
-	buf.WriteString(fmt.Sprintf(`"version":"%s"`, pr.Version))
+	buf.WriteString(`"version":` + jsonString(pr.Version))
+	if pr.Naming != "" {
+		buf.WriteString(`,"naming":` + jsonString(pr.Naming))
+	}
+
+	variantOrder := make([]string, 0, len(pr.Variants))
+	for variantName := range pr.Variants {
+		variantOrder = append(variantOrder, variantName)
+	}
+	sort.Slice(variantOrder, func(i, j int) bool {
+		a, b := pr.Variants[variantOrder[i]], pr.Variants[variantOrder[j]]
+		if a.Order != b.Order {
+			return a.Order < b.Order
+		}
+		return variantOrder[i] < variantOrder[j]
+	})
 
-	variantOrder := []string{"latte", "frappe", "macchiato", "mocha"}
 	for _, variantName := range variantOrder {
-		if variant, exists := pr.Variants[variantName]; exists {
-			buf.WriteString(",")
-			buf.WriteString(fmt.Sprintf(`"%s":`, variantName))
+		buf.WriteString(",")
+		buf.WriteString(jsonString(variantName) + ":")
 
-			variantJSON, err := variant.MarshalJSON()
-			if err != nil {
-				return nil, err
-			}
-			buf.Write(variantJSON)
+		variantJSON, err := pr.Variants[variantName].MarshalJSON()
+		if err != nil {
+			return nil, err
 		}
+		buf.Write(variantJSON)
 	}
 
 	buf.WriteString("}")
@@ -37,41 +53,90 @@ func (pv PaletteVariant) MarshalJSON() ([]byte, error) {
 	var buf strings.Builder
 	buf.WriteString("{")
 
-	buf.WriteString(fmt.Sprintf(`"name":"%s"`, pv.Name))
-	buf.WriteString(fmt.Sprintf(`,"emoji":"%s"`, pv.Emoji))
+	buf.WriteString(`"name":` + jsonString(pv.Name))
+	buf.WriteString(`,"emoji":` + jsonString(pv.Emoji))
 	buf.WriteString(fmt.Sprintf(`,"order":%d`, pv.Order))
 	buf.WriteString(fmt.Sprintf(`,"dark":%t`, pv.Dark))
 
 	buf.WriteString(`,"colors":{`)
-	colorOrder := []string{
-		"rosewater", "flamingo", "pink", "mauve", "red", "maroon", "peach", "yellow",
-		"green", "teal", "sky", "sapphire", "blue", "lavender", "text", "subtext1",
-		"subtext0", "overlay2", "overlay1", "overlay0", "surface2", "surface1",
-		"surface0", "base", "mantle", "crust",
+	// Colors listed in ColorOrder come first. Extensions follow the standard
+	// colors so that nothing in the variant is dropped from the output.
+	position := make(map[string]int, len(pv.ColorOrder))
+	for i, id := range pv.ColorOrder {
+		position[id] = i
 	}
+	colorOrder := make([]string, 0, len(pv.PaletteColors))
+	for colorName := range pv.PaletteColors {
+		colorOrder = append(colorOrder, colorName)
+	}
+	sort.Slice(colorOrder, func(i, j int) bool {
+		pa, aListed := position[colorOrder[i]]
+		pb, bListed := position[colorOrder[j]]
+		if aListed != bListed {
+			return aListed
+		}
+		if aListed {
+			return pa < pb
+		}
 
-	first := true
-	for _, colorName := range colorOrder {
-		if color, exists := pv.PaletteColors[colorName]; exists {
-			if !first {
+		a, b := pv.PaletteColors[colorOrder[i]], pv.PaletteColors[colorOrder[j]]
+		if a.Extension != b.Extension {
+			return b.Extension
+		}
+		if a.Order != b.Order {
+			return a.Order < b.Order
+		}
+		return colorOrder[i] < colorOrder[j]
+	})
+
+	for i, colorName := range colorOrder {
+		if i > 0 {
+			buf.WriteString(",")
+		}
+
+		buf.WriteString(jsonString(colorName) + ":")
+		colorJSON, err := json.Marshal(pv.PaletteColors[colorName])
+		if err != nil {
+			return nil, err
+		}
+		buf.Write(colorJSON)
+	}
+	buf.WriteString("}")
+
+	if len(pv.TranslucentColors) > 0 {
+		buf.WriteString(`,"translucentColors":{`)
+
+		translucentOrder := make([]string, 0, len(pv.TranslucentColors))
+		for id := range pv.TranslucentColors {
+			translucentOrder = append(translucentOrder, id)
+		}
+		sort.Slice(translucentOrder, func(i, j int) bool {
+			a, b := pv.TranslucentColors[translucentOrder[i]], pv.TranslucentColors[translucentOrder[j]]
+			if a.Order != b.Order {
+				return a.Order < b.Order
+			}
+			return translucentOrder[i] < translucentOrder[j]
+		})
+
+		for i, id := range translucentOrder {
+			if i > 0 {
 				buf.WriteString(",")
 			}
-			first = false
 
-			buf.WriteString(fmt.Sprintf(`"%s":`, colorName))
-			colorJSON, err := json.Marshal(color)
+			buf.WriteString(jsonString(id) + ":")
+			colorJSON, err := json.Marshal(pv.TranslucentColors[id])
 			if err != nil {
 				return nil, err
 			}
 			buf.Write(colorJSON)
 		}
+		buf.WriteString("}")
 	}
-	buf.WriteString("}")
 
 	buf.WriteString(`,"ansiColors":{`)
 	ansiOrder := []string{"black", "red", "green", "yellow", "blue", "magenta", "cyan", "white"}
 
-	first = true
+	first := true
 	for _, ansiName := range ansiOrder {
 		if ansiColor, exists := pv.AnsiPaletteColors[ansiName]; exists {
 			if !first {
@@ -79,7 +144,7 @@ func (pv PaletteVariant) MarshalJSON() ([]byte, error) {
 			}
 			first = false
 
-			buf.WriteString(fmt.Sprintf(`"%s":`, ansiName))
+			buf.WriteString(jsonString(ansiName) + ":")
 			ansiJSON, err := json.Marshal(ansiColor)
 			if err != nil {
 				return nil, err
@@ -89,10 +154,274 @@ func (pv PaletteVariant) MarshalJSON() ([]byte, error) {
 	}
 	buf.WriteString("}")
 
+	if len(pv.ANSI256) > 0 {
+		ansi256JSON, err := json.Marshal(pv.ANSI256)
+		if err != nil {
+			return nil, err
+		}
+		buf.WriteString(`,"ansi256":`)
+		buf.Write(ansi256JSON)
+	}
+
 	buf.WriteString("}")
 	return []byte(buf.String()), nil
 }
 
+// jsonString quotes s as a JSON string. HTML characters are left as they are,
+// as the output is never embedded in HTML.
+func jsonString(s string) string {
+	var buf bytes.Buffer
+	encoder := json.NewEncoder(&buf)
+	encoder.SetEscapeHTML(false)
+	encoder.Encode(s)
+	return strings.TrimSuffix(buf.String(), "\n")
+}
+
+func (pr *PaletteResult) UnmarshalJSON(data []byte) error {
+	var top map[string]json.RawMessage
+	if err := json.Unmarshal(data, &top); err != nil {
+		return err
+	}
+
+	result := PaletteResult{Variants: make(map[string]PaletteVariant)}
+	for key, raw := range top {
+		if key == "version" {
+			if err := json.Unmarshal(raw, &result.Version); err != nil {
+				return fmt.Errorf("version: %w", err)
+			}
+			continue
+		}
+		if key == "naming" {
+			if err := json.Unmarshal(raw, &result.Naming); err != nil {
+				return fmt.Errorf("naming: %w", err)
+			}
+			continue
+		}
+
+		var variant PaletteVariant
+		if err := json.Unmarshal(raw, &variant); err != nil {
+			return fmt.Errorf("variant %q: %w", key, err)
+		}
+		result.Variants[key] = variant
+	}
+
+	*pr = result
+	return nil
+}
+
+// paletteVariantJSON has the same fields as PaletteVariant but none of its
+// methods, so it decodes with the default struct tags.
+type paletteVariantJSON PaletteVariant
+
+// UnmarshalJSON keeps the order of the colors in the document, so that the
+// variant is written back the same way.
+func (pv *PaletteVariant) UnmarshalJSON(data []byte) error {
+	var variant paletteVariantJSON
+	if err := json.Unmarshal(data, &variant); err != nil {
+		return err
+	}
+
+	var raw struct {
+		Colors json.RawMessage `json:"colors"`
+	}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+	colorOrder, err := ObjectKeys(raw.Colors)
+	if err != nil {
+		return fmt.Errorf("colors: %w", err)
+	}
+
+	*pv = PaletteVariant(variant)
+	pv.ColorOrder = colorOrder
+	return nil
+}
+
+// ParseJSON decodes a palette.json document. In strict mode every field
+// without omitempty must be present and unknown fields are rejected.
+func ParseJSON(data []byte, strict bool) (PaletteResult, error) {
+	if strict {
+		if err := checkPaletteFields(data); err != nil {
+			return PaletteResult{}, err
+		}
+	}
+
+	var palette PaletteResult
+	if err := json.Unmarshal(data, &palette); err != nil {
+		return PaletteResult{}, fmt.Errorf("failed to parse JSON: %w", err)
+	}
+
+	return palette, nil
+}
+
+func ReadJSON(reader io.Reader, strict bool) (PaletteResult, error) {
+	data, err := io.ReadAll(reader)
+	if err != nil {
+		return PaletteResult{}, fmt.Errorf("failed to read JSON: %w", err)
+	}
+
+	return ParseJSON(data, strict)
+}
+
+func ReadJSONFile(filename string, strict bool) (PaletteResult, error) {
+	data, err := os.ReadFile(filename)
+	if err != nil {
+		return PaletteResult{}, fmt.Errorf("failed to read file: %w", err)
+	}
+
+	return ParseJSON(data, strict)
+}
+
+func checkPaletteFields(data []byte) error {
+	var top map[string]json.RawMessage
+	if err := json.Unmarshal(data, &top); err != nil {
+		return fmt.Errorf("failed to parse JSON: %w", err)
+	}
+
+	var errs []error
+	if _, exists := top["version"]; !exists {
+		errs = append(errs, fmt.Errorf("missing field \"version\""))
+	}
+
+	keys := make([]string, 0, len(top))
+	for key := range top {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	variantType := reflect.TypeOf(PaletteVariant{})
+	for _, key := range keys {
+		switch key {
+		case "version":
+			continue
+		case "naming":
+			var naming string
+			if err := json.Unmarshal(top[key], &naming); err != nil {
+				errs = append(errs, fmt.Errorf("naming: %w", err))
+			}
+			continue
+		}
+		errs = append(errs, checkFields(top[key], variantType, key)...)
+	}
+
+	return errors.Join(errs...)
+}
+
+// checkFields walks raw alongside t and reports missing and unknown fields
+// using the json struct tags of t.
+func checkFields(raw json.RawMessage, t reflect.Type, path string) []error {
+	if t.Kind() == reflect.Pointer {
+		if bytes.Equal(bytes.TrimSpace(raw), []byte("null")) {
+			return nil
+		}
+		t = t.Elem()
+	}
+
+	switch t.Kind() {
+	case reflect.Map:
+		var values map[string]json.RawMessage
+		if err := json.Unmarshal(raw, &values); err != nil {
+			return []error{fmt.Errorf("%s: %w", path, err)}
+		}
+
+		keys := make([]string, 0, len(values))
+		for key := range values {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+
+		var errs []error
+		for _, key := range keys {
+			errs = append(errs, checkFields(values[key], t.Elem(), path+"."+key)...)
+		}
+		return errs
+
+	case reflect.Slice:
+		var values []json.RawMessage
+		if err := json.Unmarshal(raw, &values); err != nil {
+			return []error{fmt.Errorf("%s: %w", path, err)}
+		}
+
+		var errs []error
+		for i, value := range values {
+			errs = append(errs, checkFields(value, t.Elem(), fmt.Sprintf("%s[%d]", path, i))...)
+		}
+		return errs
+
+	case reflect.Struct:
+		var values map[string]json.RawMessage
+		if err := json.Unmarshal(raw, &values); err != nil {
+			return []error{fmt.Errorf("%s: %w", path, err)}
+		}
+
+		var errs []error
+		known := make(map[string]bool)
+		for i := 0; i < t.NumField(); i++ {
+			field := t.Field(i)
+			name, options, _ := strings.Cut(field.Tag.Get("json"), ",")
+			if name == "" || name == "-" {
+				continue
+			}
+			known[name] = true
+
+			value, exists := values[name]
+			if !exists {
+				if !strings.Contains(options, "omitempty") {
+					errs = append(errs, fmt.Errorf("%s: missing field %q", path, name))
+				}
+				continue
+			}
+			errs = append(errs, checkFields(value, field.Type, path+"."+name)...)
+		}
+
+		var unknown []string
+		for name := range values {
+			if !known[name] {
+				unknown = append(unknown, name)
+			}
+		}
+		sort.Strings(unknown)
+		for _, name := range unknown {
+			errs = append(errs, fmt.Errorf("%s: unknown field %q", path, name))
+		}
+		return errs
+	}
+
+	return nil
+}
+
+// ObjectKeys returns the keys of a JSON object in the order they appear.
+func ObjectKeys(data json.RawMessage) ([]string, error) {
+	if len(data) == 0 || string(data) == "null" {
+		return nil, nil
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	if _, err := decoder.Token(); err != nil {
+		return nil, err
+	}
+
+	var keys []string
+	for decoder.More() {
+		token, err := decoder.Token()
+		if err != nil {
+			return nil, err
+		}
+		key, ok := token.(string)
+		if !ok {
+			return nil, fmt.Errorf("expected object key, found %v", token)
+		}
+		keys = append(keys, key)
+
+		var value json.RawMessage
+		if err := decoder.Decode(&value); err != nil {
+			return nil, err
+		}
+	}
+
+	return keys, nil
+}
+
 func WriteJSON(palette PaletteResult, writer io.Writer) error {
 	var jsonData []byte
 	var err error
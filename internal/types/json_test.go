@@ -0,0 +1,253 @@
+package types_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/openpalettestandard/openpalette/internal/palette"
+	"github.com/openpalettestandard/openpalette/internal/types"
+)
+
+// roundTripConfig exercises the optional parts of a variant: an interpolated
+// ramp, translucent colors and ANSI overrides.
+const roundTripConfig = `{
+	"version": "1.0.0",
+	"variants": {
+		"night": {
+			"name": "Night",
+			"dark": true,
+			"colors": {
+				"red": {"name": "Red", "hex": "#f38ba8", "accent": true},
+				"green": {"name": "Green", "hex": "#a6e3a1", "accent": true},
+				"yellow": {"name": "Yellow", "hex": "#f9e2af", "accent": true},
+				"blue": {"name": "Blue", "hex": "#89b4fa", "accent": true},
+				"pink": {"name": "Pink", "hex": "#f5c2e7", "accent": true},
+				"mauve": {"name": "Mauve", "hex": "oklch(0.79 0.12 305)", "accent": true},
+				"teal": {"name": "Teal", "hex": "#94e2d5", "accent": true},
+				"text": {"name": "Text", "hex": "#cdd6f4", "accent": false},
+				"base": {"name": "Base", "hex": "#1e1e2e", "accent": false}
+			},
+			"translucent": {
+				"selection": {"name": "Selection", "from": "overlay2", "alpha": 0.25}
+			},
+			"ansi": {
+				"colors": {"magenta": "mauve", "brightBlack": "overlay0"}
+			}
+		}
+	}
+}`
+
+func TestRoundTrip(t *testing.T) {
+	configFile := filepath.Join(t.TempDir(), "config.json")
+	if err := os.WriteFile(configFile, []byte(roundTripConfig), 0644); err != nil {
+		t.Fatalf("writing config: %v", err)
+	}
+
+	tests := map[string]func(palette.Options) (types.PaletteResult, error){
+		"default": func(opts palette.Options) (types.PaletteResult, error) {
+			return palette.Generate(opts), nil
+		},
+		"config": func(opts palette.Options) (types.PaletteResult, error) {
+			return palette.GenerateFromConfig(configFile, opts)
+		},
+	}
+
+	for name, generate := range tests {
+		for _, opts := range []palette.Options{
+			{},
+			{IncludeOKLCH: true, IncludeWideGamut: true, IncludeANSI256: true},
+		} {
+			result, err := generate(opts)
+			if err != nil {
+				t.Fatalf("%s: generating palette: %v", name, err)
+			}
+			generated, err := json.MarshalIndent(result, "", "  ")
+			if err != nil {
+				t.Fatalf("%s: marshaling generated palette: %v", name, err)
+			}
+
+			parsed, err := types.ParseJSON(generated, true)
+			if err != nil {
+				t.Fatalf("%s: parsing generated palette with %+v: %v", name, opts, err)
+			}
+
+			var buf bytes.Buffer
+			if err := types.WriteJSON(parsed, &buf); err != nil {
+				t.Fatalf("%s: writing parsed palette: %v", name, err)
+			}
+
+			if !bytes.Equal(generated, buf.Bytes()) {
+				t.Errorf("%s: round trip with %+v changed the output", name, opts)
+			}
+		}
+	}
+
+	result, err := palette.GenerateFromConfig(configFile, palette.Options{IncludeANSI256: true})
+	if err != nil {
+		t.Fatalf("generating palette: %v", err)
+	}
+	night := result.Variants["night"]
+	if len(night.TranslucentColors) != 1 || len(night.ANSI256) != 256 {
+		t.Fatalf("expected the config to produce translucent colors and ansi256, got %d and %d", len(night.TranslucentColors), len(night.ANSI256))
+	}
+	if got, want := night.AnsiPaletteColors["magenta"].Normal.Hex, night.PaletteColors["mauve"].Hex; got != want {
+		t.Errorf("expected the magenta override %s, got %s", want, got)
+	}
+}
+
+func TestParseJSONStrict(t *testing.T) {
+	generated, err := json.Marshal(palette.Generate(palette.Options{}))
+	if err != nil {
+		t.Fatalf("marshaling generated palette: %v", err)
+	}
+
+	tests := []struct {
+		name   string
+		modify func(raw map[string]any)
+		want   string
+	}{
+		{
+			name:   "missing version",
+			modify: func(raw map[string]any) { delete(raw, "version") },
+			want:   `missing field "version"`,
+		},
+		{
+			name: "unknown variant field",
+			modify: func(raw map[string]any) {
+				raw["latte"].(map[string]any)["flavor"] = "sweet"
+			},
+			want: `latte: unknown field "flavor"`,
+		},
+		{
+			name: "missing color field",
+			modify: func(raw map[string]any) {
+				colors := raw["latte"].(map[string]any)["colors"].(map[string]any)
+				delete(colors["red"].(map[string]any), "hex")
+			},
+			want: `latte.colors.red: missing field "hex"`,
+		},
+		{
+			name: "unknown ansi field",
+			modify: func(raw map[string]any) {
+				ansi := raw["latte"].(map[string]any)["ansiColors"].(map[string]any)
+				ansi["red"].(map[string]any)["normal"].(map[string]any)["italic"] = true
+			},
+			want: `latte.ansiColors.red.normal: unknown field "italic"`,
+		},
+	}
+
+	for _, tt := range tests {
+		var raw map[string]any
+		if err := json.Unmarshal(generated, &raw); err != nil {
+			t.Fatalf("decoding generated palette: %v", err)
+		}
+		tt.modify(raw)
+
+		data, err := json.Marshal(raw)
+		if err != nil {
+			t.Fatalf("%s: marshaling modified palette: %v", tt.name, err)
+		}
+
+		if _, err := types.ParseJSON(data, false); err != nil {
+			t.Errorf("%s: lenient parse failed: %v", tt.name, err)
+		}
+
+		_, err = types.ParseJSON(data, true)
+		if err == nil || !strings.Contains(err.Error(), tt.want) {
+			t.Errorf("%s: expected error containing %q, got %v", tt.name, tt.want, err)
+		}
+	}
+}
+
+func TestMarshalCustomVariants(t *testing.T) {
+	rawVariants, _, err := palette.LoadFromFile("")
+	if err != nil {
+		t.Fatalf("loading default variants: %v", err)
+	}
+
+	night, day := rawVariants[0], rawVariants[0]
+	night.ID, day.ID = "night", "day"
+	rawVariants = []types.RawVariant{night, day}
+
+	data, err := json.Marshal(palette.GenerateFromVariants(rawVariants, "1.0.0", palette.Options{}))
+	if err != nil {
+		t.Fatalf("marshaling palette: %v", err)
+	}
+
+	nightAt, dayAt := bytes.Index(data, []byte(`"night":`)), bytes.Index(data, []byte(`"day":`))
+	if nightAt < 0 || dayAt < 0 {
+		t.Fatalf("expected both custom variants in output, got night=%d day=%d", nightAt, dayAt)
+	}
+	if nightAt > dayAt {
+		t.Errorf("expected variants in Order, found night after day")
+	}
+}
+
+func TestMarshalExtensionColors(t *testing.T) {
+	rawVariants, _, err := palette.LoadFromFile("")
+	if err != nil {
+		t.Fatalf("loading default variants: %v", err)
+	}
+	rawVariants[0].PaletteColors = append([]types.RawPaletteColor{
+		{ID: "brand", Name: "Brand", Hex: "#ff00aa", Accent: true},
+	}, rawVariants[0].PaletteColors...)
+
+	result := palette.GenerateFromVariants(rawVariants, "1.0.0", palette.Options{})
+	data, err := json.Marshal(result)
+	if err != nil {
+		t.Fatalf("marshaling palette: %v", err)
+	}
+
+	brandAt, crustAt := bytes.Index(data, []byte(`"brand":`)), bytes.Index(data, []byte(`"crust":`))
+	if brandAt < 0 {
+		t.Fatal("expected extension color in output")
+	}
+	if brandAt < crustAt {
+		t.Error("expected extension color after the standard colors")
+	}
+
+	parsed, err := types.ParseJSON(data, true)
+	if err != nil {
+		t.Fatalf("parsing palette: %v", err)
+	}
+	if brand := parsed.Variants["latte"].PaletteColors["brand"]; !brand.Extension {
+		t.Errorf("expected brand to be marked as an extension, got %+v", brand)
+	}
+}
+
+func TestMarshalEscapesStrings(t *testing.T) {
+	result := types.PaletteResult{
+		Version: "1.0.0",
+		Variants: map[string]types.PaletteVariant{
+			`night "blue"`: {
+				Name:  `Night "Blue"`,
+				Emoji: `\`,
+				Dark:  true,
+				PaletteColors: map[string]types.PaletteColor{
+					`brand"`: {Name: "Brand", Hex: "#ff00aa", Extension: true},
+				},
+			},
+		},
+	}
+
+	data, err := json.Marshal(result)
+	if err != nil {
+		t.Fatalf("marshaling palette: %v", err)
+	}
+
+	parsed, err := types.ParseJSON(data, false)
+	if err != nil {
+		t.Fatalf("parsing palette: %v", err)
+	}
+	variant, exists := parsed.Variants[`night "blue"`]
+	if !exists || variant.Name != `Night "Blue"` || variant.Emoji != `\` {
+		t.Errorf("expected the variant to survive a round trip, got %+v", parsed.Variants)
+	}
+	if _, exists := variant.PaletteColors[`brand"`]; !exists {
+		t.Errorf("expected the color to survive a round trip, got %+v", variant.PaletteColors)
+	}
+}
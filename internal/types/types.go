@@ -1,32 +1,55 @@
 package types
 
 type RGB struct {
-	R int `json:"r"`
-	G int `json:"g"`
-	B int `json:"b"`
+	R int      `json:"r"`
+	G int      `json:"g"`
+	B int      `json:"b"`
+	A *float64 `json:"a,omitempty"`
 }
 
 type HSL struct {
-	H float64 `json:"h"`
-	S float64 `json:"s"`
-	L float64 `json:"l"`
+	H float64  `json:"h"`
+	S float64  `json:"s"`
+	L float64  `json:"l"`
+	A *float64 `json:"a,omitempty"`
+}
+
+type RGBCoords struct {
+	R float64  `json:"r"`
+	G float64  `json:"g"`
+	B float64  `json:"b"`
+	A *float64 `json:"a,omitempty"`
+}
+
+type OKLCH struct {
+	L float64  `json:"l"`
+	C float64  `json:"c"`
+	H float64  `json:"h"`
+	A *float64 `json:"a,omitempty"`
 }
 
 type PaletteColor struct {
-	Name   string `json:"name"`
-	Order  int    `json:"order"`
-	Hex    string `json:"hex"`
-	RGB    RGB    `json:"rgb"`
-	HSL    HSL    `json:"hsl"`
-	Accent bool   `json:"accent"`
+	Name       string     `json:"name"`
+	Order      int        `json:"order"`
+	Hex        string     `json:"hex"`
+	RGB        RGB        `json:"rgb"`
+	HSL        HSL        `json:"hsl"`
+	OKLCH      *OKLCH     `json:"oklch,omitempty"`
+	DisplayP3  *RGBCoords `json:"displayP3,omitempty"`
+	Rec2020    *RGBCoords `json:"rec2020,omitempty"`
+	Accent     bool       `json:"accent"`
+	OutOfGamut bool       `json:"outOfGamut,omitempty"`
+	Extension  bool       `json:"extension,omitempty"`
 }
 
 type ANSIVariant struct {
-	Name string `json:"name"`
-	Hex  string `json:"hex"`
-	RGB  RGB    `json:"rgb"`
-	HSL  HSL    `json:"hsl"`
-	Code int    `json:"code"`
+	Name       string `json:"name"`
+	Hex        string `json:"hex"`
+	RGB        RGB    `json:"rgb"`
+	HSL        HSL    `json:"hsl"`
+	OKLCH      *OKLCH `json:"oklch,omitempty"`
+	Code       int    `json:"code"`
+	OutOfGamut bool   `json:"outOfGamut,omitempty"`
 }
 
 type ANSIColor struct {
@@ -37,9 +60,10 @@ type ANSIColor struct {
 }
 
 type ANSIMapping struct {
-	NormalCode int
-	BrightCode int
-	Mapping    string
+	NormalCode    int
+	BrightCode    int
+	Mapping       string
+	BrightMapping string
 }
 
 type PaletteVariant struct {
@@ -48,11 +72,18 @@ type PaletteVariant struct {
 	Order             int                     `json:"order"`
 	Dark              bool                    `json:"dark"`
 	PaletteColors     map[string]PaletteColor `json:"colors"`
+	TranslucentColors map[string]PaletteColor `json:"translucentColors,omitempty"`
 	AnsiPaletteColors map[string]ANSIColor    `json:"ansiColors"`
+	ANSI256           []ANSIVariant           `json:"ansi256,omitempty"`
+
+	// ColorOrder lists the color IDs in output order. IDs missing from it
+	// follow in the default order, standard colors before extensions.
+	ColorOrder []string `json:"-"`
 }
 
 type PaletteResult struct {
 	Version  string                    `json:"version"`
+	Naming   string                    `json:"naming,omitempty"`
 	Variants map[string]PaletteVariant `json:"-"`
 }
 
@@ -60,14 +91,33 @@ type RawPaletteColor struct {
 	ID     string
 	Name   string
 	Hex    string
+	Source string
 	Accent bool
 }
 
+type RawTranslucentColor struct {
+	ID    string
+	Name  string
+	From  string
+	Alpha float64
+}
+
 type RawVariant struct {
-	ID            string
-	Name          string
-	Emoji         string
-	Dark          bool
-	PaletteColors []RawPaletteColor
+	ID                string
+	Name              string
+	Emoji             string
+	Dark              bool
+	PaletteColors     []RawPaletteColor
+	TranslucentColors []RawTranslucentColor
+	ANSIBrightSpace   string
+	ANSIColors        map[string]string
+	ANSIBright        *RawANSIBright
+	RampSpace         string
+	Naming            string
 }
 
+type RawANSIBright struct {
+	Lightness *float64
+	Chroma    *float64
+	Hue       *float64
+}
@@ -0,0 +1,519 @@
+package validate
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/openpalettestandard/openpalette/internal/accessibility"
+	"github.com/openpalettestandard/openpalette/internal/color"
+	"github.com/openpalettestandard/openpalette/internal/palette"
+	"github.com/openpalettestandard/openpalette/internal/types"
+)
+
+type Severity string
+
+const (
+	SeverityMust   Severity = "MUST"
+	SeverityShould Severity = "SHOULD"
+	SeverityInfo   Severity = "INFO"
+)
+
+type Rule struct {
+	ID       string   `json:"id"`
+	Section  string   `json:"section"`
+	Severity Severity `json:"severity"`
+	Summary  string   `json:"summary"`
+}
+
+var (
+	RuleAccentCount    = Rule{ID: "accent-count", Section: "7.1", Severity: SeverityMust, Summary: "exactly 14 accent colors"}
+	RuleSemanticCount  = Rule{ID: "semantic-count", Section: "7.1", Severity: SeverityMust, Summary: "exactly 12 semantic elements"}
+	RuleRequiredFields = Rule{ID: "required-fields", Section: "7.1", Severity: SeverityMust, Summary: "all required fields present"}
+	RuleHexFormat      = Rule{ID: "hex-format", Section: "7.1", Severity: SeverityMust, Summary: "valid hex color codes"}
+	RuleRGBRange       = Rule{ID: "rgb-range", Section: "7.1", Severity: SeverityMust, Summary: "RGB values within 0-255"}
+	RuleHSLRange       = Rule{ID: "hsl-range", Section: "7.1", Severity: SeverityMust, Summary: "HSL values within h: 0-360, s/l: 0-1"}
+	RuleNameLowercase  = Rule{ID: "name-lowercase", Section: "7.2", Severity: SeverityMust, Summary: "color names use lowercase"}
+	RuleNameCharacters = Rule{ID: "name-characters", Section: "7.2", Severity: SeverityMust, Summary: "no spaces or special characters in color names"}
+	RuleVariantName    = Rule{ID: "variant-name", Section: "7.2", Severity: SeverityShould, Summary: "palette name is descriptive and unique"}
+	RuleContrastMust   = Rule{ID: "contrast-required", Section: "6.1", Severity: SeverityMust, Summary: "text and subtext1 on base meet WCAG AA"}
+	RuleContrastShould = Rule{ID: "contrast-recommended", Section: "6.1", Severity: SeverityShould, Summary: "subtext0 and accents on base meet WCAG AA"}
+	RuleExtension      = Rule{ID: "extension-color", Section: "3.3", Severity: SeverityInfo, Summary: "colors outside the standard set are extensions"}
+)
+
+var Rules = []Rule{
+	RuleAccentCount,
+	RuleSemanticCount,
+	RuleRequiredFields,
+	RuleHexFormat,
+	RuleRGBRange,
+	RuleHSLRange,
+	RuleNameLowercase,
+	RuleNameCharacters,
+	RuleVariantName,
+	RuleContrastMust,
+	RuleContrastShould,
+	RuleExtension,
+}
+
+var (
+	hexPattern  = regexp.MustCompile(`^#[0-9a-fA-F]{6}([0-9a-fA-F]{2})?$`)
+	namePattern = regexp.MustCompile(`^[a-zA-Z0-9]+$`)
+)
+
+type Finding struct {
+	Rule     string   `json:"rule"`
+	Section  string   `json:"section"`
+	Severity Severity `json:"severity"`
+	Variant  string   `json:"variant,omitempty"`
+	Color    string   `json:"color,omitempty"`
+	Message  string   `json:"message"`
+}
+
+type Report struct {
+	Findings []Finding `json:"findings"`
+}
+
+func (r Report) Failed() bool {
+	return r.Count(SeverityMust) > 0
+}
+
+func (r Report) Count(severity Severity) int {
+	count := 0
+	for _, finding := range r.Findings {
+		if finding.Severity == severity {
+			count++
+		}
+	}
+	return count
+}
+
+func (r *Report) add(rule Rule, variant, color, format string, args ...interface{}) {
+	r.Findings = append(r.Findings, Finding{
+		Rule:     rule.ID,
+		Section:  rule.Section,
+		Severity: rule.Severity,
+		Variant:  variant,
+		Color:    color,
+		Message:  fmt.Sprintf(format, args...),
+	})
+}
+
+type variantInput struct {
+	ID     string
+	Name   *string
+	Dark   *bool
+	Colors []colorInput
+
+	// names is the naming scheme chosen by a config file. When nil the
+	// scheme is detected from the color IDs.
+	names *palette.NameSet
+}
+
+type colorInput struct {
+	ID     string
+	Name   *string
+	Order  *int
+	Hex    *string
+	RGB    *rgbInput
+	HSL    *hslInput
+	Accent *bool
+
+	// derived marks colors read from a config file, where order, rgb and
+	// hsl are computed by the generator rather than written by the author.
+	derived bool
+}
+
+type rgbInput struct {
+	R *float64 `json:"r"`
+	G *float64 `json:"g"`
+	B *float64 `json:"b"`
+	A *float64 `json:"a"`
+}
+
+type hslInput struct {
+	H *float64 `json:"h"`
+	S *float64 `json:"s"`
+	L *float64 `json:"l"`
+	A *float64 `json:"a"`
+}
+
+func File(filename string) (Report, error) {
+	data, err := os.ReadFile(filename)
+	if err != nil {
+		return Report{}, fmt.Errorf("reading file: %w", err)
+	}
+
+	var top map[string]json.RawMessage
+	if err := json.Unmarshal(data, &top); err != nil {
+		return Report{}, fmt.Errorf("parsing JSON: %w", err)
+	}
+
+	if _, isConfig := top["variants"]; isConfig {
+		var config palette.ConfigFile
+		if err := json.Unmarshal(data, &config); err != nil {
+			return Report{}, fmt.Errorf("parsing JSON config: %w", err)
+		}
+		return Config(config), nil
+	}
+
+	return Palette(data)
+}
+
+func Palette(data []byte) (Report, error) {
+	var top map[string]json.RawMessage
+	if err := json.Unmarshal(data, &top); err != nil {
+		return Report{}, fmt.Errorf("parsing JSON: %w", err)
+	}
+
+	var report Report
+	if _, exists := top["version"]; !exists {
+		report.add(RuleRequiredFields, "", "", "missing field \"version\"")
+	}
+
+	// Palettes written before the naming scheme was recorded fall back to
+	// detecting it from the color IDs.
+	var names *palette.NameSet
+	if raw, exists := top["naming"]; exists {
+		var naming string
+		if err := json.Unmarshal(raw, &naming); err != nil {
+			return Report{}, fmt.Errorf("parsing naming: %w", err)
+		}
+		ns, err := palette.LookupNameSet(naming)
+		if err != nil {
+			report.add(RuleRequiredFields, "", "", "%v", err)
+		} else {
+			names = &ns
+		}
+	}
+
+	var variants []variantInput
+	for _, id := range sortedKeys(top) {
+		if id == "version" || id == "naming" {
+			continue
+		}
+
+		var doc struct {
+			Name   *string `json:"name"`
+			Dark   *bool   `json:"dark"`
+			Colors map[string]struct {
+				Name   *string   `json:"name"`
+				Order  *int      `json:"order"`
+				Hex    *string   `json:"hex"`
+				RGB    *rgbInput `json:"rgb"`
+				HSL    *hslInput `json:"hsl"`
+				Accent *bool     `json:"accent"`
+			} `json:"colors"`
+		}
+		if err := json.Unmarshal(top[id], &doc); err != nil {
+			return Report{}, fmt.Errorf("parsing variant %q: %w", id, err)
+		}
+		if doc.Colors == nil {
+			report.add(RuleRequiredFields, id, "", "missing field \"colors\"")
+		}
+
+		variant := variantInput{ID: id, Name: doc.Name, Dark: doc.Dark, names: names}
+		for _, colorID := range sortedKeys(doc.Colors) {
+			c := doc.Colors[colorID]
+			variant.Colors = append(variant.Colors, colorInput{
+				ID:     colorID,
+				Name:   c.Name,
+				Order:  c.Order,
+				Hex:    c.Hex,
+				RGB:    c.RGB,
+				HSL:    c.HSL,
+				Accent: c.Accent,
+			})
+		}
+		variants = append(variants, variant)
+	}
+
+	checkVariants(&report, variants)
+	return report, nil
+}
+
+func Config(config palette.ConfigFile) Report {
+	var report Report
+
+	var names *palette.NameSet
+	if config.Naming != "" {
+		ns, err := palette.LookupNameSet(config.Naming)
+		if err != nil {
+			report.add(RuleRequiredFields, "", "", "%v", err)
+		} else {
+			names = &ns
+		}
+	}
+
+	var variants []variantInput
+	for _, id := range sortedKeys(config.Variants) {
+		cv := config.Variants[id]
+		name, dark := cv.Name, cv.Dark
+
+		variant := variantInput{ID: id, Name: &name, Dark: &dark, names: names}
+		variant.Colors = append(variant.Colors, rampColors(cv)...)
+		for _, colorID := range sortedKeys(cv.Colors) {
+			cc := cv.Colors[colorID]
+			colorName, hex, accent := cc.Name, cc.Hex, cc.Accent
+
+			// The generator renames aliased colors to the chosen scheme, so
+			// check the names the output will use.
+			if names != nil {
+				canonical := names.Canonical(colorID)
+				if _, exists := cv.Colors[canonical]; !exists {
+					colorID = canonical
+				}
+			}
+			variant.Colors = append(variant.Colors, colorInput{
+				ID:      colorID,
+				Name:    &colorName,
+				Hex:     &hex,
+				Accent:  &accent,
+				derived: true,
+			})
+		}
+		variants = append(variants, variant)
+	}
+
+	checkVariants(&report, variants)
+	return report
+}
+
+// rampColors returns the semantic elements the generator will interpolate
+// for a config variant that leaves them out.
+func rampColors(cv palette.ConfigVariant) []colorInput {
+	var rawColors []types.RawPaletteColor
+	for _, colorID := range sortedKeys(cv.Colors) {
+		cc := cv.Colors[colorID]
+		rawColors = append(rawColors, types.RawPaletteColor{ID: colorID, Name: cc.Name, Hex: cc.Hex, Source: cc.Hex})
+	}
+
+	space := color.SpaceOKLCH
+	if cv.Ramp != nil && cv.Ramp.Space != "" {
+		space = color.Space(cv.Ramp.Space)
+	}
+
+	var derived []colorInput
+	for _, rawColor := range palette.FillSemanticRamp(rawColors, cv.Dark, space) {
+		if _, exists := cv.Colors[rawColor.ID]; exists {
+			continue
+		}
+		name, hex, accent := rawColor.Name, rawColor.Hex, false
+		derived = append(derived, colorInput{
+			ID:      rawColor.ID,
+			Name:    &name,
+			Hex:     &hex,
+			Accent:  &accent,
+			derived: true,
+		})
+	}
+	return derived
+}
+
+func checkVariants(report *Report, variants []variantInput) {
+	seenNames := make(map[string]string)
+
+	for _, variant := range variants {
+		if variant.Name == nil {
+			report.add(RuleRequiredFields, variant.ID, "", "missing field \"name\"")
+		} else {
+			name := strings.TrimSpace(*variant.Name)
+			if name == "" {
+				report.add(RuleVariantName, variant.ID, "", "variant name is empty")
+			} else if other, exists := seenNames[strings.ToLower(name)]; exists {
+				report.add(RuleVariantName, variant.ID, "", "variant name %q is also used by %q", name, other)
+			} else {
+				seenNames[strings.ToLower(name)] = variant.ID
+			}
+		}
+		if variant.Dark == nil {
+			report.add(RuleRequiredFields, variant.ID, "", "missing field \"dark\"")
+		}
+
+		checkCounts(report, variant)
+		for _, c := range variant.Colors {
+			checkColor(report, variant.ID, c)
+		}
+		checkContrast(report, variant)
+	}
+}
+
+func (v variantInput) nameSet() palette.NameSet {
+	if v.names != nil {
+		return *v.names
+	}
+
+	ids := make([]string, 0, len(v.Colors))
+	for _, c := range v.Colors {
+		ids = append(ids, c.ID)
+	}
+	return palette.DetectNameSet(ids)
+}
+
+func checkCounts(report *Report, variant variantInput) {
+	names := variant.nameSet()
+	accents, semantics := 0, 0
+	present := make(map[string]bool)
+
+	for _, c := range variant.Colors {
+		if !names.IsStandard(c.ID) {
+			report.add(RuleExtension, variant.ID, c.ID, "%q is an extension color and is not counted towards the standard palette", c.ID)
+			continue
+		}
+
+		present[c.ID] = true
+		if c.Accent != nil && *c.Accent {
+			accents++
+		} else {
+			semantics++
+		}
+	}
+
+	if accents != 14 {
+		report.add(RuleAccentCount, variant.ID, "", "expected 14 accent colors, found %d", accents)
+	}
+	if semantics != 12 {
+		report.add(RuleSemanticCount, variant.ID, "", "expected 12 semantic elements, found %d", semantics)
+	}
+	for _, id := range names.Semantics {
+		if !present[id] {
+			report.add(RuleSemanticCount, variant.ID, id, "missing semantic element %q", id)
+		}
+	}
+}
+
+func checkColor(report *Report, variantID string, c colorInput) {
+	if strings.ToLower(c.ID) != c.ID {
+		report.add(RuleNameLowercase, variantID, c.ID, "color name %q is not lowercase", c.ID)
+	}
+	if !namePattern.MatchString(c.ID) {
+		report.add(RuleNameCharacters, variantID, c.ID, "color name %q contains spaces or special characters", c.ID)
+	}
+
+	var missing []string
+	if c.Name == nil {
+		missing = append(missing, "name")
+	}
+	if c.Hex == nil {
+		missing = append(missing, "hex")
+	}
+	if c.Accent == nil {
+		missing = append(missing, "accent")
+	}
+	if !c.derived {
+		if c.Order == nil {
+			missing = append(missing, "order")
+		}
+		if c.RGB == nil || c.RGB.R == nil || c.RGB.G == nil || c.RGB.B == nil {
+			missing = append(missing, "rgb")
+		}
+		if c.HSL == nil || c.HSL.H == nil || c.HSL.S == nil || c.HSL.L == nil {
+			missing = append(missing, "hsl")
+		}
+	}
+	for _, field := range missing {
+		report.add(RuleRequiredFields, variantID, c.ID, "missing field %q", field)
+	}
+
+	if c.Hex != nil && !validHex(*c.Hex, c.derived) {
+		if c.derived {
+			report.add(RuleHexFormat, variantID, c.ID, "%q is not a valid CSS color", *c.Hex)
+		} else {
+			report.add(RuleHexFormat, variantID, c.ID, "%q is not a valid #rrggbb or #rrggbbaa hex code", *c.Hex)
+		}
+	}
+
+	if c.RGB != nil {
+		for _, channel := range []struct {
+			name  string
+			value *float64
+		}{{"r", c.RGB.R}, {"g", c.RGB.G}, {"b", c.RGB.B}} {
+			if channel.value == nil {
+				continue
+			}
+			v := *channel.value
+			if v < 0 || v > 255 || v != float64(int(v)) {
+				report.add(RuleRGBRange, variantID, c.ID, "rgb.%s = %v is not an integer in 0-255", channel.name, v)
+			}
+		}
+		if c.RGB.A != nil && (*c.RGB.A < 0 || *c.RGB.A > 1) {
+			report.add(RuleRGBRange, variantID, c.ID, "rgb.a = %v is outside 0-1", *c.RGB.A)
+		}
+	}
+
+	if c.HSL != nil {
+		if c.HSL.H != nil && (*c.HSL.H < 0 || *c.HSL.H > 360) {
+			report.add(RuleHSLRange, variantID, c.ID, "hsl.h = %v is outside 0-360", *c.HSL.H)
+		}
+		if c.HSL.S != nil && (*c.HSL.S < 0 || *c.HSL.S > 1) {
+			report.add(RuleHSLRange, variantID, c.ID, "hsl.s = %v is outside 0-1", *c.HSL.S)
+		}
+		if c.HSL.L != nil && (*c.HSL.L < 0 || *c.HSL.L > 1) {
+			report.add(RuleHSLRange, variantID, c.ID, "hsl.l = %v is outside 0-1", *c.HSL.L)
+		}
+		if c.HSL.A != nil && (*c.HSL.A < 0 || *c.HSL.A > 1) {
+			report.add(RuleHSLRange, variantID, c.ID, "hsl.a = %v is outside 0-1", *c.HSL.A)
+		}
+	}
+}
+
+// validHex also accepts any CSS color syntax in config files, which the
+// generator normalizes to hex.
+func validHex(value string, derived bool) bool {
+	if hexPattern.MatchString(value) {
+		return true
+	}
+	if derived {
+		_, err := color.Parse(value)
+		return err == nil
+	}
+	return false
+}
+
+func checkContrast(report *Report, variant variantInput) {
+	pv := types.PaletteVariant{PaletteColors: make(map[string]types.PaletteColor)}
+	for _, c := range variant.Colors {
+		if c.Hex == nil || !validHex(*c.Hex, c.derived) {
+			continue
+		}
+
+		hex := *c.Hex
+		if parsed, err := color.Parse(hex); err == nil {
+			hex = parsed.ToString()
+		}
+
+		pc := types.PaletteColor{Hex: hex}
+		if c.Order != nil {
+			pc.Order = *c.Order
+		}
+		if c.Accent != nil {
+			pc.Accent = *c.Accent
+		}
+		pv.PaletteColors[c.ID] = pc
+	}
+
+	for _, pair := range accessibility.Contrast(variant.ID, pv).Pairs {
+		if pair.AA || pair.Level == accessibility.LevelInformational {
+			continue
+		}
+
+		rule := RuleContrastShould
+		if pair.Level == accessibility.LevelRequired {
+			rule = RuleContrastMust
+		}
+		report.add(rule, variant.ID, pair.Foreground, "%s on %s has contrast %.2f:1, below %.1f:1",
+			pair.Foreground, pair.Background, pair.Ratio, accessibility.RatioAA)
+	}
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
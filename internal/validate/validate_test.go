@@ -0,0 +1,175 @@
+package validate
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/openpalettestandard/openpalette/internal/palette"
+	"github.com/openpalettestandard/openpalette/internal/types"
+)
+
+func TestDefaultPaletteIsCompliant(t *testing.T) {
+	data, err := json.Marshal(palette.Generate(palette.Options{}))
+	if err != nil {
+		t.Fatalf("marshaling default palette: %v", err)
+	}
+
+	report, err := Palette(data)
+	if err != nil {
+		t.Fatalf("validating default palette: %v", err)
+	}
+
+	if report.Failed() {
+		t.Errorf("expected no MUST failures, got %+v", report.Findings)
+	}
+}
+
+func TestPaletteNaming(t *testing.T) {
+	result := palette.Generate(palette.Options{Naming: string(palette.NamingOpenPalette)})
+	if result.Naming != string(palette.NamingOpenPalette) {
+		t.Errorf("expected naming %q, got %q", palette.NamingOpenPalette, result.Naming)
+	}
+
+	tests := map[string]string{
+		string(palette.NamingOpenPalette): RuleAccentCount.ID,
+		"material":                        RuleRequiredFields.ID,
+	}
+
+	for naming, want := range tests {
+		// The Catppuccin colors only pass if the recorded scheme is ignored.
+		result := palette.Generate(palette.Options{})
+		result.Naming = naming
+		data, err := json.Marshal(result)
+		if err != nil {
+			t.Fatalf("marshaling palette: %v", err)
+		}
+
+		report, err := Palette(data)
+		if err != nil {
+			t.Fatalf("validating palette: %v", err)
+		}
+
+		found := false
+		for _, finding := range report.Findings {
+			found = found || finding.Rule == want
+		}
+		if !found {
+			t.Errorf("naming %s: expected a %s finding, got %+v", naming, want, report.Findings)
+		}
+	}
+}
+
+func TestPaletteFindings(t *testing.T) {
+	data := []byte(`{
+		"latte": {
+			"name": "Latte",
+			"colors": {
+				"Red": {"name": "Red", "order": 0, "hex": "#d20f3", "rgb": {"r": 256, "g": 15, "b": 57}, "hsl": {"h": 347, "s": 1.2, "l": 0.44}, "accent": true},
+				"base": {"name": "Base", "hex": "#eff1f5", "accent": false}
+			}
+		}
+	}`)
+
+	report, err := Palette(data)
+	if err != nil {
+		t.Fatalf("validating palette: %v", err)
+	}
+
+	expected := map[string]bool{
+		RuleRequiredFields.ID: true,
+		RuleAccentCount.ID:    true,
+		RuleSemanticCount.ID:  true,
+		RuleHexFormat.ID:      true,
+		RuleRGBRange.ID:       true,
+		RuleHSLRange.ID:       true,
+		RuleNameLowercase.ID:  true,
+	}
+
+	found := make(map[string]bool)
+	for _, finding := range report.Findings {
+		found[finding.Rule] = true
+	}
+
+	for rule := range expected {
+		if !found[rule] {
+			t.Errorf("expected a %s finding, got %+v", rule, report.Findings)
+		}
+	}
+
+	if !report.Failed() {
+		t.Error("expected report to fail")
+	}
+}
+
+func TestConfigSkipsDerivedFields(t *testing.T) {
+	config := palette.ConfigFile{
+		Variants: map[string]palette.ConfigVariant{
+			"latte": {
+				Name: "Latte",
+				Colors: map[string]palette.ConfigColor{
+					"base": {Name: "Base", Hex: "#eff1f5"},
+				},
+			},
+		},
+	}
+
+	for _, finding := range Config(config).Findings {
+		if finding.Rule == RuleRequiredFields.ID {
+			t.Errorf("unexpected required-fields finding for config: %+v", finding)
+		}
+	}
+}
+
+func TestExtensionColors(t *testing.T) {
+	rawVariants, _, err := palette.LoadFromFile("")
+	if err != nil {
+		t.Fatalf("loading default variants: %v", err)
+	}
+	rawVariants[0].PaletteColors = append(rawVariants[0].PaletteColors,
+		types.RawPaletteColor{ID: "orange", Name: "Orange", Hex: "#fe640b", Accent: true})
+
+	data, err := json.Marshal(palette.GenerateFromVariants(rawVariants, "1.0.0", palette.Options{}))
+	if err != nil {
+		t.Fatalf("marshaling palette: %v", err)
+	}
+
+	report, err := Palette(data)
+	if err != nil {
+		t.Fatalf("validating palette: %v", err)
+	}
+
+	if report.Failed() {
+		t.Errorf("expected extension color not to fail validation, got %+v", report.Findings)
+	}
+
+	var extensions []Finding
+	for _, finding := range report.Findings {
+		if finding.Rule == RuleExtension.ID {
+			extensions = append(extensions, finding)
+		}
+	}
+	if len(extensions) != 1 || extensions[0].Color != "orange" {
+		t.Errorf("expected one extension finding for orange, got %+v", extensions)
+	}
+}
+
+func TestConfigInterpolatedRamp(t *testing.T) {
+	config := palette.ConfigFile{
+		Variants: map[string]palette.ConfigVariant{
+			"mocha": {
+				Name: "Mocha",
+				Dark: true,
+				Colors: map[string]palette.ConfigColor{
+					"text": {Name: "Text", Hex: "#cdd6f4"},
+					"base": {Name: "Base", Hex: "#1e1e2e"},
+				},
+			},
+		},
+	}
+
+	for _, finding := range Config(config).Findings {
+		if finding.Rule == RuleSemanticCount.ID {
+			t.Errorf("unexpected semantic-count finding for interpolated ramp: %+v", finding)
+		}
+	}
+}